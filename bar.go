@@ -5,16 +5,21 @@ import (
 	"cmp"
 	"context"
 	"errors"
+	"hash"
 	"io"
 	"iter"
+	"maps"
 	"math"
+	"reflect"
 	"slices"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/acarl005/stripansi"
 	"github.com/mattn/go-runewidth"
 	"github.com/vbauerster/mpb/v8/decor"
+	"github.com/vbauerster/mpb/v8/internal"
 )
 
 // Bar represents a progress bar.
@@ -26,10 +31,21 @@ type Bar struct {
 	shutdown       int
 	frameCh        chan *renderFrame
 	operateState   chan func(*bState)
+	activate       chan struct{}
+	active         bool  // holds a slot, see WithMaxActiveBars
+	task           bool  // holds a task slot, see WithTaskLimit
+	listed         bool  // is in the heap while pending
+	retired        bool  // is done without being activated
+	queuedTotal    int64 // total while queued and not in the heap
 	container      *Progress
 	bs             *bState
 	bsOk           chan struct{}
 	ewmaDecorators []decor.EwmaDecorator
+	limiter        *internal.Limiter
+	shareMu        sync.Mutex
+	share          *internal.Limiter // share of container's limit, see WithRateLimit
+	unshared       bool              // bar is done, so it doesn't join again
+	scale          int64             // see BarScale
 }
 
 type decorSyncTable [2][]*decor.Sync
@@ -38,6 +54,7 @@ type rowProducer func(decor.Statistics) (io.Reader, error)
 // bState is actual bar's state.
 type bState struct {
 	waitFor      *Bar // key for (*pState).queueBars
+	after        []*Bar
 	id           int
 	priority     int
 	reqWidth     int
@@ -45,19 +62,47 @@ type bState struct {
 	total1       int64
 	current      int64
 	refill       int64
+	scale        int64
+	retries      int
+	message      string
+	label        string
+	created      time.Time
+	started      time.Time // first update of current
+	updated      time.Time // last update of current
+	finished     time.Time
+	pausedAt     time.Time
+	pausedFor    time.Duration // accumulated since started
+	watched      time.Time     // last progress, see BarStallTimeout
+	stallTimeout time.Duration
+	stallAbort   time.Duration
+	stallTimer   *time.Timer // see BarStallAbort
+	values       map[any]any
+	cause        error
+	deadline     time.Time
 	rowProducers iter.Seq[rowProducer]
 	filler       BarFiller
+	pendingMeta  func(string) string
+	limiter      *internal.Limiter
+	shared       *internal.Pool // container's limiter
 	buffers      [3]*bytes.Buffer
 	decorGroups  [2][]decor.Decorator
 	trimSpace    bool
 	rmOnComplete bool
 	aborted      bool
+	skipped      bool
+	afterAny     bool
 	noPop        bool
+	pending      bool // waits for a slot or for prerequisites
+	task         bool // started by (*Progress).Go
+	paused       bool
+	verifying    bool // holds complete event until digest is verified
 }
 
 type renderFrame struct {
 	rows         []io.Reader
+	stat         decor.Statistics
 	err          error
+	cause        error
 	rmOnComplete bool
 	noPop        bool
 }
@@ -114,28 +159,114 @@ func (b *Bar) ProxyWriter(w io.Writer) (io.WriteCloser, error) {
 	}
 }
 
+// ProxyReaderHash wraps io.Reader same way ProxyReader does, additionally
+// feeding every read byte into `h`. Fast path of io.WriterTo is retained
+// if `r` implements it. If `expected` is not nil, complete event is held
+// until EOF or Close, whichever comes first, at which point digest of `h`
+// is compared with `expected`. On mismatch bar is aborted with
+// *ChecksumError cause, which is also returned by Read (or WriteTo) instead
+// of EOF, or by Close. On read error bar is aborted with the error as its
+// cause. Panics if `r` or `h` is nil.
+func (b *Bar) ProxyReaderHash(r io.Reader, h hash.Hash, expected []byte) (io.ReadCloser, error) {
+	if r == nil {
+		panic(errors.New("expected non nil io.Reader"))
+	}
+	if h == nil {
+		panic(errors.New("expected non nil hash.Hash"))
+	}
+	if expected == nil {
+		return b.ProxyReader(newHashReader(r, h))
+	}
+	select {
+	case b.operateState <- func(s *bState) { s.verifying = true }:
+		return newHashVerifier(b, newProxyReader(b, newHashReader(r, h)), h, expected), nil
+	case <-b.ctx.Done():
+		return nil, ErrDone[*Bar]{nil}
+	}
+}
+
+// ProxyReaderLimited is a shorthand for calling (*Bar).SetRateLimit and
+// then (*Bar).ProxyReader. Returned reader is throttled to `bytesPerSec`
+// which can be adjusted at runtime with (*Bar).SetRateLimit.
+func (b *Bar) ProxyReaderLimited(r io.Reader, bytesPerSec int64) (io.ReadCloser, error) {
+	b.SetRateLimit(bytesPerSec)
+	return b.ProxyReader(r)
+}
+
+// ProxyWriterLimited is a shorthand for calling (*Bar).SetRateLimit and
+// then (*Bar).ProxyWriter. Returned writer is throttled to `bytesPerSec`
+// which can be adjusted at runtime with (*Bar).SetRateLimit.
+func (b *Bar) ProxyWriterLimited(w io.Writer, bytesPerSec int64) (io.WriteCloser, error) {
+	b.SetRateLimit(bytesPerSec)
+	return b.ProxyWriter(w)
+}
+
+// SetRateLimit sets throughput limit in bytes per second, zero or negative
+// value removes the limit. It can be called at any time and affects proxies
+// which are already in use. See BarRateLimit and WithRateLimit.
+func (b *Bar) SetRateLimit(bytesPerSec int64) {
+	b.limiter.SetLimit(bytesPerSec)
+}
+
+// RateLimit returns bar's own throughput limit in bytes per second.
+// Zero means no limit.
+func (b *Bar) RateLimit() int64 {
+	return b.limiter.Limit()
+}
+
+// Context returns bar's context, which is done once bar is completed or
+// aborted and has been rendered the last time. If bar is aborted with a
+// cause, context.Cause of returned context reports it.
+func (b *Bar) Context() context.Context {
+	return b.ctx
+}
+
 // ID returns id of the bar.
 func (b *Bar) ID() int {
 	result := make(chan int, 1)
-	select {
-	case b.operateState <- func(s *bState) { result <- s.id }:
+	if b.query(func(s *bState) { result <- s.id }) {
 		return <-result
-	case <-b.ctx.Done():
-		b.Wait()
-		return b.bs.id
 	}
+	b.Wait()
+	return b.bs.id
 }
 
 // Current returns bar's current value, in other words sum of all increments.
 func (b *Bar) Current() int64 {
 	result := make(chan int64, 1)
-	select {
-	case b.operateState <- func(s *bState) { result <- s.current }:
+	if b.query(func(s *bState) { result <- s.current }) {
 		return <-result
-	case <-b.ctx.Done():
-		b.Wait()
-		return b.bs.current
 	}
+	b.Wait()
+	return b.bs.current
+}
+
+// CurrentFloat returns bar's current value divided by scale set with
+// BarScale.
+func (b *Bar) CurrentFloat() float64 {
+	return float64(b.Current()) / float64(b.scale)
+}
+
+// SetCurrentFloat sets bar's current to value multiplied by scale set
+// with BarScale. See (*Bar).SetCurrent.
+func (b *Bar) SetCurrentFloat(current float64) {
+	b.SetCurrent(b.fixedPoint(current))
+}
+
+// IncrFloat increments bar's current by n multiplied by scale set with
+// BarScale. See (*Bar).IncrInt64.
+func (b *Bar) IncrFloat(n float64) {
+	b.IncrInt64(b.fixedPoint(n))
+}
+
+// SetTotalFloat sets bar's total to value multiplied by scale set with
+// BarScale. See (*Bar).SetTotal.
+func (b *Bar) SetTotalFloat(total float64, forceComplete bool) {
+	b.SetTotal(b.fixedPoint(total), forceComplete)
+}
+
+func (b *Bar) fixedPoint(v float64) int64 {
+	return int64(math.Round(v * float64(b.scale)))
 }
 
 // SetRefill sets refill flag with specified amount.
@@ -152,6 +283,52 @@ func (b *Bar) SetRefill(amount int64) {
 	}
 }
 
+// SetMessage sets bar's status message, which is displayed by
+// decor.Message decorator.
+func (b *Bar) SetMessage(message string) {
+	select {
+	case b.operateState <- func(s *bState) { s.message = message }:
+	case <-b.ctx.Done():
+	}
+}
+
+// SetLabel sets bar's label, which is displayed by decor.Label decorator.
+func (b *Bar) SetLabel(label string) {
+	select {
+	case b.operateState <- func(s *bState) { s.label = label }:
+	case <-b.ctx.Done():
+	}
+}
+
+// SetValue attaches value to the bar under provided key, so it can be
+// read by decorators and bar fillers with decor.Statistics.Value. Key
+// should be comparable and is better to be of custom type, same as with
+// context.WithValue. Nil value removes the key. Panics if key is nil.
+func (b *Bar) SetValue(key, value any) {
+	if key == nil {
+		panic(errors.New("expected non nil key"))
+	}
+	if !reflect.TypeOf(key).Comparable() {
+		panic(errors.New("expected comparable key"))
+	}
+	select {
+	case b.operateState <- func(s *bState) {
+		// copy on write, so Statistics of previous renders stay intact
+		values := maps.Clone(s.values)
+		if value == nil {
+			delete(values, key)
+		} else {
+			if values == nil {
+				values = make(map[any]any)
+			}
+			values[key] = value
+		}
+		s.values = values
+	}:
+	case <-b.ctx.Done():
+	}
+}
+
 // SetRefillCurrent sets refill to the current amount.
 func (b *Bar) SetRefillCurrent() {
 	b.SetRefill(math.MaxInt64)
@@ -165,8 +342,7 @@ func (b *Bar) SetRefillCurrent() {
 // lead to a panic.
 func (b *Bar) TraverseDecorators() (iter.Seq2[int, decor.Decorator], error) {
 	res := make(chan iter.Seq2[int, decor.Decorator], 1)
-	select {
-	case b.operateState <- func(s *bState) {
+	if b.query(func(s *bState) {
 		done := make(chan struct{})
 		res <- func(yield func(int, decor.Decorator) bool) {
 			defer close(done)
@@ -179,11 +355,10 @@ func (b *Bar) TraverseDecorators() (iter.Seq2[int, decor.Decorator], error) {
 			}
 		}
 		<-done
-	}:
+	}) {
 		return <-res, nil
-	case <-b.ctx.Done():
-		return nil, ErrDone[*Bar]{nil}
 	}
+	return nil, ErrDone[*Bar]{nil}
 }
 
 // DecoratorAverageAdjust adjusts decorators implementing decor.AverageDecorator
@@ -210,7 +385,7 @@ func (b *Bar) EnableTriggerComplete() {
 	case b.operateState <- func(s *bState) {
 		s.total0 = max(cmp.Or(s.total1, s.total0), 0)
 		if s.completed() {
-			b.done()
+			b.done(s)
 		}
 	}:
 	case <-b.ctx.Done():
@@ -230,9 +405,12 @@ func (b *Bar) SetTotal(total int64, forceComplete bool) {
 			s.total1 = total
 		}
 		if forceComplete {
+			if s.current != s.total1 {
+				s.stamp()
+			}
 			s.total0, s.current = s.total1, s.total1
 			if s.completed() {
-				b.done()
+				b.done(s)
 			}
 		}
 	}:
@@ -247,9 +425,12 @@ func (b *Bar) SetCurrent(current int64) {
 	}
 	select {
 	case b.operateState <- func(s *bState) {
+		if s.current != current {
+			s.stamp()
+		}
 		s.current = current
 		if s.completed() {
-			b.done()
+			b.done(s)
 		}
 	}:
 	case <-b.ctx.Done():
@@ -270,9 +451,12 @@ func (b *Bar) IncrBy(n int) {
 func (b *Bar) IncrInt64(n int64) {
 	select {
 	case b.operateState <- func(s *bState) {
+		if n != 0 {
+			s.stamp()
+		}
 		s.current += n
 		if s.completed() {
-			b.done()
+			b.done(s)
 		}
 	}:
 	case <-b.ctx.Done():
@@ -294,9 +478,12 @@ func (b *Bar) EwmaIncrBy(n int, iterDur time.Duration) {
 func (b *Bar) EwmaIncrInt64(n int64, iterDur time.Duration) {
 	select {
 	case b.operateState <- func(s *bState) {
+		if n != 0 {
+			s.stamp()
+		}
 		s.current += n
 		if s.completed() {
-			b.done()
+			b.done(s)
 		}
 	}:
 		for _, d := range b.ewmaDecorators {
@@ -316,9 +503,12 @@ func (b *Bar) EwmaSetCurrent(current int64, iterDur time.Duration) {
 	select {
 	case b.operateState <- func(s *bState) {
 		n := current - s.current
+		if n != 0 {
+			s.stamp()
+		}
 		s.current += n
 		if s.completed() {
-			b.done()
+			b.done(s)
 		}
 		ch <- n
 	}:
@@ -330,6 +520,67 @@ func (b *Bar) EwmaSetCurrent(current int64, iterDur time.Duration) {
 	}
 }
 
+// Reset puts the bar back to its initial state with provided total, as
+// if it has just been constructed, retaining its ID and priority. Number
+// of resets is reported by decor.Statistics.Retries. Decorators which
+// implement decor.ResetListener are notified, so built-in average, EWMA
+// and elapsed decorators restart. Intended for retries as alternative to
+// aborting the bar and constructing a new one. Reset has no effect once
+// bar is completed or aborted.
+func (b *Bar) Reset(total int64) {
+	select {
+	case b.operateState <- func(s *bState) {
+		if s.aborted || s.completed() {
+			return
+		}
+		s.total0, s.total1 = cmp.Or(total, -1), 0
+		s.current, s.refill = 0, 0
+		s.started, s.updated, s.finished = time.Time{}, time.Time{}, time.Time{}
+		s.watched = time.Now()
+		if s.stallTimer != nil {
+			s.stallTimer.Reset(s.stallAbort)
+		}
+		s.pausedFor = 0
+		if s.paused {
+			s.pausedAt = time.Now()
+		}
+		s.retries++
+		for _, group := range s.decorGroups {
+			decoratorOnReset(group)
+		}
+	}:
+	case <-b.ctx.Done():
+	}
+}
+
+// Pause puts the bar into paused state, which is reported by
+// decor.Statistics.Paused. Time spent paused is left out by built-in
+// average and elapsed decorators. Pause is no-op if bar is paused already.
+func (b *Bar) Pause() {
+	select {
+	case b.operateState <- func(s *bState) {
+		if !s.paused {
+			s.paused, s.pausedAt = true, time.Now()
+		}
+	}:
+	case <-b.ctx.Done():
+	}
+}
+
+// Resume puts paused bar back into running state.
+func (b *Bar) Resume() {
+	select {
+	case b.operateState <- func(s *bState) {
+		if s.paused {
+			s.pausedFor = s.pausedTime(time.Now())
+			s.paused, s.pausedAt = false, time.Time{}
+			s.watched = time.Now()
+		}
+	}:
+	case <-b.ctx.Done():
+	}
+}
+
 // SetPriority changes bar's order among multiple bars. Zero is highest
 // priority, i.e. bar will be on top. If you don't need to set priority
 // dynamically, better use BarPriority option.
@@ -341,14 +592,49 @@ func (b *Bar) SetPriority(priority int) {
 // if bar is already in complete state. If drop is true bar will be
 // removed as well.
 func (b *Bar) Abort(drop bool) {
+	b.abort(drop, nil)
+}
+
+// AbortWithCause is same as Abort(false), but additionally records the
+// cause, which is reported by (*Bar).Cause and decor.Statistics.
+func (b *Bar) AbortWithCause(cause error) {
+	b.abort(false, cause)
+}
+
+func (b *Bar) abort(drop bool, cause error) {
+	select {
+	case b.operateState <- func(s *bState) { b.abortState(s, drop, cause) }:
+	case <-b.ctx.Done():
+	}
+}
+
+func (b *Bar) abortState(s *bState, drop bool, cause error) {
+	if s.aborted || s.completed() {
+		return
+	}
+	s.aborted = true
+	s.rmOnComplete = drop
+	s.cause = cause
+	if cause != nil && b.container.noRenderMode {
+		// cause of the first call wins, so it has to precede b.done
+		b.cancel(cause)
+	}
+	b.done(s)
+}
+
+// releaseComplete releases complete event held by ProxyReaderHash, or
+// aborts the bar if cause isn't nil.
+func (b *Bar) releaseComplete(cause error) {
 	select {
 	case b.operateState <- func(s *bState) {
-		if s.aborted || s.completed() {
+		if cause != nil {
+			b.abortState(s, false, cause)
 			return
 		}
-		s.aborted = true
-		s.rmOnComplete = drop
-		b.done()
+		s.verifying = false
+		if s.completed() {
+			b.done(s)
+		}
 	}:
 	case <-b.ctx.Done():
 	}
@@ -357,38 +643,55 @@ func (b *Bar) Abort(drop bool) {
 // Aborted reports whether the bar is in aborted state.
 func (b *Bar) Aborted() bool {
 	result := make(chan bool, 1)
-	select {
-	case b.operateState <- func(s *bState) { result <- s.aborted }:
+	if b.query(func(s *bState) { result <- s.aborted }) {
+		return <-result
+	}
+	b.Wait()
+	return b.bs.aborted
+}
+
+// Skipped reports whether the bar is aborted because its prerequisites,
+// set by BarAfter or BarAfterAny, can't be satisfied.
+func (b *Bar) Skipped() bool {
+	result := make(chan bool, 1)
+	if b.query(func(s *bState) { result <- s.skipped }) {
+		return <-result
+	}
+	b.Wait()
+	return b.bs.skipped
+}
+
+// Cause returns the cause bar has been aborted with. Returns nil if bar is
+// not aborted or is aborted without a cause. If bar is aborted due to its
+// container's context being done, cause of that context is returned.
+func (b *Bar) Cause() error {
+	result := make(chan error, 1)
+	if b.query(func(s *bState) { result <- s.cause }) {
 		return <-result
-	case <-b.ctx.Done():
-		b.Wait()
-		return b.bs.aborted
 	}
+	b.Wait()
+	return b.bs.cause
 }
 
 // Completed reports whether the bar is in completed state.
 func (b *Bar) Completed() bool {
 	result := make(chan bool, 1)
-	select {
-	case b.operateState <- func(s *bState) { result <- s.completed() }:
+	if b.query(func(s *bState) { result <- s.completed() }) {
 		return <-result
-	case <-b.ctx.Done():
-		b.Wait()
-		return b.bs.completed()
 	}
+	b.Wait()
+	return b.bs.completed()
 }
 
 // AbortedOrCompleted reports whether a bar is in aborted or completed state.
 // Faster and atomic version of `(*Bar).Aborted() || (*Bar).Completed()`.
 func (b *Bar) AbortedOrCompleted() bool {
 	result := make(chan bool, 1)
-	select {
-	case b.operateState <- func(s *bState) { result <- s.aborted || s.completed() }:
+	if b.query(func(s *bState) { result <- s.aborted || s.completed() }) {
 		return <-result
-	case <-b.ctx.Done():
-		b.Wait()
-		return b.bs.aborted || b.bs.completed()
 	}
+	b.Wait()
+	return b.bs.aborted || b.bs.completed()
 }
 
 // Wait blocks until bar is completed or aborted.
@@ -405,24 +708,68 @@ func (b *Bar) serve(bs *bState) {
 		<-bs.waitFor.ctx.Done()
 		bs.waitFor = nil
 	}
+	for bs.pending {
+		select {
+		case <-b.activate:
+			bs.pending = false
+		case op := <-b.operateState:
+			total := bs.total()
+			op(bs)
+			if bs.aborted || bs.completed() {
+				// done while pending, has to be rendered to shut down
+				bs.pending = false
+				go b.container.retirePending(b)
+			} else if bs.total() != total {
+				go b.container.updateQueuedTotal(b, bs.total())
+			}
+		case <-b.ctx.Done():
+			bs.pending = false
+		}
+	}
+	var deadline <-chan time.Time
+	if !bs.deadline.IsZero() {
+		timer := time.NewTimer(time.Until(bs.deadline))
+		defer timer.Stop()
+		deadline = timer.C
+	}
+	bs.watched = time.Now()
+	var stall <-chan time.Time
+	bs.stallTimer = bs.stallAbortTimer()
+	if bs.stallTimer != nil {
+		defer bs.stallTimer.Stop()
+		stall = bs.stallTimer.C
+	}
 	for {
 		select {
 		case op := <-b.operateState:
 			op(bs)
+		case <-deadline:
+			b.abortState(bs, false, context.DeadlineExceeded)
+		case <-stall:
+			b.checkStall(bs)
 		case <-b.ctx.Done():
 			if bs.aborted {
 				return
 			}
-			bs.aborted = !bs.completed() || !errors.Is(context.Cause(b.ctx), context.Canceled)
+			cause := context.Cause(b.ctx)
+			bs.aborted = !bs.completed() || !errors.Is(cause, context.Canceled)
+			if bs.aborted {
+				bs.cause = cause
+			}
+			if bs.finished.IsZero() {
+				bs.finished = time.Now()
+			}
 			return
 		}
 	}
 }
 
-func (b *Bar) render(tw int) {
+func (b *Bar) render(tw int, cs *decor.ContainerStatistics) {
 	fn := func(s *bState) {
 		frame := new(renderFrame)
 		stat := s.newStatistics(tw)
+		stat.Container = cs
+		frame.stat = stat
 		for p := range s.rowProducers {
 			r, err := p(stat)
 			if err != nil && frame.err == nil {
@@ -433,34 +780,49 @@ func (b *Bar) render(tw int) {
 			}
 			frame.rows = append(frame.rows, r)
 		}
+		if s.pending && s.pendingMeta != nil && frame.err == nil {
+			for i, r := range frame.rows {
+				frame.rows[i] = applyRowMeta(r, s.pendingMeta)
+			}
+		}
 		if s.aborted || s.completed() {
 			frame.rmOnComplete = s.rmOnComplete
 			frame.noPop = s.noPop
+			frame.cause = s.cause
 			// post increment makes sure OnComplete decorators are rendered
 			b.shutdown++
 		}
 		b.frameCh <- frame
 	}
-	select {
-	case b.operateState <- fn:
-	case <-b.ctx.Done():
+	if !b.query(fn) {
 		b.Wait()
 		fn(b.bs)
 	}
 }
 
+// query sends read only op. Returns false if bar is done.
+func (b *Bar) query(op func(*bState)) bool {
+	select {
+	case b.operateState <- op:
+		return true
+	case <-b.ctx.Done():
+		return false
+	}
+}
+
 func (b *Bar) wSyncTable() decorSyncTable {
 	result := make(chan decorSyncTable, 1)
-	select {
-	case b.operateState <- func(s *bState) { result <- s.wSyncTable() }:
+	if b.query(func(s *bState) { result <- s.wSyncTable() }) {
 		return <-result
-	case <-b.ctx.Done():
-		b.Wait()
-		return b.bs.wSyncTable()
 	}
+	b.Wait()
+	return b.bs.wSyncTable()
 }
 
-func (b *Bar) done() {
+func (b *Bar) done(s *bState) {
+	if s.finished.IsZero() {
+		s.finished = time.Now()
+	}
 	if b.container.noRenderMode {
 		b.cancel(nil)
 	} else {
@@ -591,19 +953,64 @@ func (s *bState) isQueue() bool {
 }
 
 func (s *bState) completed() bool {
-	return s.total0 >= 0 && s.current >= s.total0
+	return !s.verifying && s.total0 >= 0 && s.current >= s.total0
+}
+
+func (s *bState) stamp() {
+	now := time.Now()
+	if s.started.IsZero() {
+		s.started = now
+	}
+	s.updated = now
+	s.watched = now
+}
+
+// pausedTime returns time spent paused since started, including current
+// pause if any.
+func (s *bState) pausedTime(now time.Time) time.Duration {
+	if !s.paused || s.started.IsZero() {
+		return s.pausedFor
+	}
+	if s.pausedAt.Before(s.started) {
+		return s.pausedFor + now.Sub(s.started)
+	}
+	return s.pausedFor + now.Sub(s.pausedAt)
+}
+
+// total returns total as seen by decorators.
+func (s *bState) total() int64 {
+	return max(cmp.Or(s.total1, s.total0), 0)
 }
 
 func (s *bState) newStatistics(tw int) decor.Statistics {
+	now := time.Now()
+	stalledFor := s.stalledFor(now)
 	return decor.Statistics{
 		AvailableWidth: tw,
 		RequestedWidth: s.reqWidth,
 		ID:             s.id,
-		Total:          max(cmp.Or(s.total1, s.total0), 0),
+		Total:          s.total(),
 		Current:        s.current,
 		Refill:         s.refill,
+		Scale:          s.scale,
 		Completed:      s.completed(),
 		Aborted:        s.aborted,
+		Skipped:        s.skipped,
+		Pending:        s.pending,
+		Cause:          s.cause,
+		RateLimit:      cmp.Or(s.limiter.Limit(), s.shared.Limit()),
+		Retries:        s.retries,
+		Message:        s.message,
+		Label:          s.label,
+		Values:         s.values,
+		Created:        s.created,
+		Started:        s.started,
+		Updated:        s.updated,
+		Finished:       s.finished,
+		Paused:         s.paused,
+		PausedFor:      s.pausedTime(now),
+		Stalled:        stalledFor != 0,
+		StalledFor:     stalledFor,
 	}
 }
 
@@ -615,6 +1022,14 @@ func decoratorOnShutdown(group []decor.Decorator) {
 	}
 }
 
+func decoratorOnReset(group []decor.Decorator) {
+	for _, d := range group {
+		if d, ok := unwrap(d).(decor.ResetListener); ok {
+			d.OnReset()
+		}
+	}
+}
+
 func unwrap(d decor.Decorator) decor.Decorator {
 	if d, ok := d.(decor.Wrapper); ok {
 		return unwrap(d.Unwrap())
@@ -658,3 +1073,15 @@ func makeRowProducers(base rowProducer, top bool, fillers ...BarFiller) iter.Seq
 		}
 	}
 }
+
+// applyRowMeta applies meta to a row, excluding its trailing new line.
+func applyRowMeta(row io.Reader, meta func(string) string) io.Reader {
+	var buf strings.Builder
+	_, _ = io.Copy(&buf, row)
+	str, ok := strings.CutSuffix(buf.String(), "\n")
+	str = meta(str)
+	if ok {
+		str += "\n"
+	}
+	return strings.NewReader(str)
+}
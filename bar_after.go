@@ -0,0 +1,75 @@
+package mpb
+
+import "errors"
+
+// ErrSkipped is the cause a bar is aborted with, if its prerequisites set
+// by BarAfter or BarAfterAny can't be satisfied.
+var ErrSkipped = errors.New("skipped: prerequisite bar aborted")
+
+var errForeignPrerequisite = errors.New("prerequisite bar belongs to another container")
+
+// checkPrerequisites validates prerequisites of a bar being constructed.
+// It's called before bar's id is taken, so rejected bar leaves no trace.
+// There is no need to check for a dependency cycle: prerequisites have to
+// exist before the bar is constructed and they can't be changed later, so
+// the graph can't have a cycle by construction.
+func (p *Progress) checkPrerequisites(after []*Bar) error {
+	for _, b := range after {
+		if b.container != p {
+			return errForeignPrerequisite
+		}
+	}
+	return nil
+}
+
+// awaitPrerequisites blocks until prerequisites are done and reports
+// whether they are satisfied.
+func awaitPrerequisites(bars []*Bar, anyOf bool) bool {
+	ch := make(chan bool, len(bars))
+	for _, b := range bars {
+		go func() {
+			b.Wait()
+			ch <- !b.Aborted()
+		}()
+	}
+	for range bars {
+		// first completed satisfies any of, first aborted fails all of
+		if ok := <-ch; ok == anyOf {
+			return ok
+		}
+	}
+	return !anyOf
+}
+
+// resolveDependent activates dependent bar, once its prerequisites are
+// satisfied. If the bar is limited by WithMaxActiveBars or WithTaskLimit
+// it's queued for a slot as any other pending bar.
+func (p *Progress) resolveDependent(b *Bar, limited, taskLimited bool) {
+	select {
+	case p.operateState <- func(s *pState) {
+		if b.retired {
+			return
+		}
+		switch {
+		case taskLimited:
+			s.pendingTasks = append(s.pendingTasks, b)
+		case limited:
+			s.pendingBars = append(s.pendingBars, b)
+		default:
+			close(b.activate)
+			return
+		}
+		s.activatePending(p.noRenderMode)
+	}:
+	case <-p.done:
+	}
+}
+
+func (b *Bar) skip() {
+	b.query(func(s *bState) {
+		if !s.aborted {
+			b.abortState(s, false, ErrSkipped)
+			s.skipped = s.aborted
+		}
+	})
+}
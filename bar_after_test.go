@@ -0,0 +1,137 @@
+package mpb_test
+
+import (
+	"errors"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/vbauerster/mpb/v8"
+)
+
+func TestBarAfter(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	a := p.AddBar(1)
+	b := p.AddBar(1)
+	c := p.AddBar(1, mpb.BarAfter(a, b))
+
+	a.Increment()
+	if c.AbortedOrCompleted() {
+		t.Fatal("Expected dependent bar to wait")
+	}
+	b.Increment()
+	c.Increment() // blocks until prerequisites are completed
+	p.Wait()
+
+	if !c.Completed() {
+		t.Error("Expected dependent bar to be completed")
+	}
+	if c.Skipped() {
+		t.Error("Expected dependent bar not to be skipped")
+	}
+}
+
+func TestBarAfterSkipped(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	a := p.AddBar(1)
+	b := p.AddBar(1)
+	c := p.AddBar(1, mpb.BarAfter(a, b))
+	d := p.AddBar(1, mpb.BarAfter(c))
+
+	a.Abort(false)
+	c.Wait()
+	d.Wait()
+	b.Increment()
+	p.Wait()
+
+	for _, bar := range []*mpb.Bar{c, d} {
+		if !bar.Skipped() || !bar.Aborted() {
+			t.Errorf("Expected bar %d to be skipped", bar.ID())
+		}
+		if cause := bar.Cause(); !errors.Is(cause, mpb.ErrSkipped) {
+			t.Errorf("Expected cause %v, got: %v", mpb.ErrSkipped, cause)
+		}
+	}
+	if b.Skipped() {
+		t.Error("Expected prerequisite bar not to be skipped")
+	}
+}
+
+func TestBarAfterAny(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	a := p.AddBar(1)
+	b := p.AddBar(1)
+	c := p.AddBar(1, mpb.BarAfterAny(a, b))
+
+	a.Abort(false)
+	b.Increment()
+	c.Increment()
+	p.Wait()
+
+	if !c.Completed() {
+		t.Error("Expected dependent bar to be completed")
+	}
+}
+
+func TestBarAfterForeignContainer(t *testing.T) {
+	p1 := mpb.New(mpb.WithOutput(io.Discard))
+	p2 := mpb.New(mpb.WithOutput(io.Discard))
+	a := p1.AddBar(1)
+
+	_, err := p2.Add(1, nil, mpb.BarAfter(a))
+	if err == nil {
+		t.Error("Expected error for prerequisite of another container")
+	}
+	// rejected bar doesn't take an id
+	b := p2.AddBar(1)
+	if b.ID() != 0 {
+		t.Errorf("Expected id 0, got: %d", b.ID())
+	}
+	a.Increment()
+	b.Increment()
+	p1.Wait()
+	p2.Wait()
+}
+
+func TestBarAfterMaxActive(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithMaxActiveBars(1))
+	a := p.AddBar(1)
+	b := p.AddBar(1, mpb.BarAfter(a))
+	c := p.AddBar(1)
+
+	bars := []*mpb.Bar{a, b, c}
+	for _, bar := range bars {
+		go bar.Increment()
+	}
+	p.Wait()
+
+	for _, bar := range bars {
+		if !bar.Completed() {
+			t.Errorf("Expected bar %d to be completed", bar.ID())
+		}
+	}
+}
+
+func TestBarAfterAbortWaiting(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithAutoRefresh())
+	a := p.AddBar(1)
+	b := p.AddBar(1, mpb.BarAfter(a))
+
+	done := make(chan struct{})
+	go func() {
+		b.SetMessage("canceled")
+		b.Abort(false)
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("Expected abort of waiting bar not to block")
+	}
+	a.Increment()
+	p.Wait()
+
+	if !b.Aborted() || b.Skipped() {
+		t.Error("Expected waiting bar to be aborted, not skipped")
+	}
+}
@@ -2,6 +2,7 @@ package mpb
 
 import (
 	"io"
+	"strings"
 
 	"github.com/mattn/go-runewidth"
 	"github.com/vbauerster/mpb/v8/decor"
@@ -35,13 +36,16 @@ type barSection struct {
 type barSections [iLen + 1]barSection
 
 type barFiller struct {
-	components [iLen]component
-	metas      [iLen + 1]func(string) string
-	flushOp    func(barSections, io.Writer) error
-	tip        struct {
+	components  [iLen]component
+	metas       [iLen + 1]func(string) string
+	flushOp     func(barSections, io.Writer) error
+	pausedMeta  func(string) string
+	stalledMeta func(string) string
+	tip         struct {
 		onComplete bool
 		count      uint
 		frames     []component
+		paused     *component
 	}
 }
 
@@ -52,6 +56,9 @@ type BarStyleComposer struct {
 	metas         [iLen + 1]func(string) string
 	tipFrames     []string
 	tipOnComplete bool
+	pausedTip     *string
+	pausedMeta    func(string) string
+	stalledMeta   func(string) string
 	rev           bool
 }
 
@@ -128,6 +135,27 @@ func (s BarStyleComposer) TipOnComplete() BarStyleComposer {
 	return s
 }
 
+// PausedTip sets tip which is displayed instead of animated tip frames,
+// while bar is paused by (*Bar).Pause.
+func (s BarStyleComposer) PausedTip(tip string) BarStyleComposer {
+	s.pausedTip = &tip
+	return s
+}
+
+// PausedMeta sets meta which is applied to the whole filler output, while
+// bar is paused by (*Bar).Pause.
+func (s BarStyleComposer) PausedMeta(fn func(string) string) BarStyleComposer {
+	s.pausedMeta = fn
+	return s
+}
+
+// StalledMeta sets meta which is applied to the whole filler output, while
+// bar is stalled, see BarStallTimeout. PausedMeta takes precedence.
+func (s BarStyleComposer) StalledMeta(fn func(string) string) BarStyleComposer {
+	s.stalledMeta = fn
+	return s
+}
+
 func (s BarStyleComposer) Reverse() BarStyleComposer {
 	s.rev = true
 	return s
@@ -160,6 +188,14 @@ func (s BarStyleComposer) Build() BarFiller {
 		bytes: []byte(s.style[iPadding]),
 	}
 	bf.tip.onComplete = s.tipOnComplete
+	if s.pausedTip != nil {
+		bf.tip.paused = &component{
+			width: runewidth.StringWidth(*s.pausedTip),
+			bytes: []byte(*s.pausedTip),
+		}
+	}
+	bf.pausedMeta = s.pausedMeta
+	bf.stalledMeta = s.stalledMeta
 	bf.tip.frames = make([]component, 0, len(s.tipFrames))
 	for _, t := range s.tipFrames {
 		bf.tip.frames = append(bf.tip.frames, component{
@@ -189,9 +225,15 @@ func (s *barFiller) Fill(w io.Writer, stat decor.Statistics) error {
 	curWidth := int(internal.PercentageRound(stat.Total, stat.Current, int64(width)))
 
 	if curWidth != 0 {
-		if !stat.Completed || s.tip.onComplete {
+		switch {
+		case stat.Paused && s.tip.paused != nil:
+			tip = *s.tip.paused
+			fillCount += tip.width
+		case !stat.Completed || s.tip.onComplete:
 			tip = s.tip.frames[s.tip.count%uint(len(s.tip.frames))]
-			s.tip.count++
+			if !stat.Paused {
+				s.tip.count++
+			}
 			fillCount += tip.width
 		}
 		switch refWidth := 0; {
@@ -218,14 +260,31 @@ func (s *barFiller) Fill(w io.Writer, stat decor.Statistics) error {
 		padding = append(padding, "…"...)
 	}
 
-	return s.flushOp(barSections{
+	sections := barSections{
 		{s.metas[iLbound], s.components[iLbound].bytes},
 		{s.metas[iRefiller], refilling},
 		{s.metas[iFiller], filling},
 		{s.metas[iLen], tip.bytes},
 		{s.metas[iPadding], padding},
 		{s.metas[iRbound], s.components[iRbound].bytes},
-	}, w)
+	}
+	var meta func(string) string
+	switch {
+	case stat.Paused:
+		meta = s.pausedMeta
+	case stat.Stalled:
+		meta = s.stalledMeta
+	}
+	if meta != nil {
+		var buf strings.Builder
+		err := s.flushOp(sections, &buf)
+		if err != nil {
+			return err
+		}
+		_, err = io.WriteString(w, meta(buf.String()))
+		return err
+	}
+	return s.flushOp(sections, w)
 }
 
 func (s barSection) flush(w io.Writer) (err error) {
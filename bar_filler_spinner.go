@@ -89,7 +89,9 @@ func (s *spinnerFiller) Fill(w io.Writer, stat decor.Statistics) error {
 	width := internal.CheckRequestedWidth(stat.RequestedWidth, stat.AvailableWidth)
 	frame := s.frames[s.count%uint(len(s.frames))]
 	frameWidth := runewidth.StringWidth(frame)
-	s.count++
+	if !stat.Paused {
+		s.count++
+	}
 
 	if width < frameWidth {
 		return nil
@@ -3,6 +3,7 @@ package mpb
 import (
 	"io"
 	"slices"
+	"time"
 
 	"github.com/vbauerster/mpb/v8/decor"
 )
@@ -61,6 +62,89 @@ func BarQueueAfter(bar *Bar) BarOption {
 	}
 }
 
+// BarAfter makes this (being constructed) bar wait until all of provided
+// bars are completed. While waiting the bar is rendered in pending state,
+// see decor.Statistics.Pending. If any of provided bars is aborted, this
+// bar is skipped, i.e. aborted with ErrSkipped cause and reported by
+// (*Bar).Skipped. Provided bars must belong to the same container,
+// otherwise (*Progress).Add returns an error.
+func BarAfter(bars ...*Bar) BarOption {
+	return func(s *bState) {
+		s.after = slices.DeleteFunc(slices.Clone(bars), func(b *Bar) bool { return b == nil })
+		s.afterAny = false
+	}
+}
+
+// BarAfterAny is same as BarAfter, but this bar waits until any of
+// provided bars is completed. The bar is skipped only if all of provided
+// bars are aborted.
+func BarAfterAny(bars ...*Bar) BarOption {
+	return func(s *bState) {
+		s.after = slices.DeleteFunc(slices.Clone(bars), func(b *Bar) bool { return b == nil })
+		s.afterAny = true
+	}
+}
+
+// BarScale sets fixed-point scale of bar's values, i.e. number of units
+// which represent 1.0 of fractional progress. For example with scale of
+// 1000, (*Bar).SetCurrentFloat(12.5) sets current to 12500. Total passed
+// to (*Progress).Add is expected to be scaled already. Values reported by
+// decor.Statistics stay scaled, decorators with no unit take scale into
+// account, so float verbs like "%.1f" display fractional part.
+func BarScale(scale int64) BarOption {
+	return func(s *bState) {
+		if scale > 0 {
+			s.scale = scale
+		}
+	}
+}
+
+// BarRateLimit sets bar's throughput limit in bytes per second. Proxies
+// constructed by (*Bar).ProxyReader and (*Bar).ProxyWriter are throttled
+// accordingly. Limit can be adjusted at runtime with (*Bar).SetRateLimit.
+func BarRateLimit(bytesPerSec int64) BarOption {
+	return func(s *bState) {
+		s.limiter.SetLimit(bytesPerSec)
+	}
+}
+
+// BarDeadline aborts the bar with context.DeadlineExceeded cause, if it's
+// neither completed nor aborted by provided time.
+func BarDeadline(deadline time.Time) BarOption {
+	return func(s *bState) {
+		s.deadline = deadline
+	}
+}
+
+// BarTimeout is a shorthand for BarDeadline(time.Now().Add(timeout)),
+// where time.Now() is evaluated at the moment the bar is added.
+func BarTimeout(timeout time.Duration) BarOption {
+	return func(s *bState) {
+		s.deadline = time.Now().Add(timeout)
+	}
+}
+
+// BarStallTimeout marks the bar as stalled, if its current isn't updated
+// for provided timeout. Stall is reported by decor.Statistics.Stalled and
+// StalledFor, built-in speed and ETA decorators display "stalled" warning
+// instead of their value. Time spent paused doesn't count.
+func BarStallTimeout(timeout time.Duration) BarOption {
+	return func(s *bState) {
+		s.stallTimeout = timeout
+	}
+}
+
+// BarStallAbort aborts the bar with ErrStalled cause, if its current isn't
+// updated for provided timeout. It's intended to be longer than timeout of
+// BarStallTimeout. Time spent paused doesn't count. As aborted bar cancels
+// its (*Bar).Context, work bound to the context is interrupted and could be
+// retried once errors.Is((*Bar).Cause(), ErrStalled) is checked.
+func BarStallAbort(timeout time.Duration) BarOption {
+	return func(s *bState) {
+		s.stallAbort = timeout
+	}
+}
+
 // BarRemoveOnComplete removes both bar's filler and its decorators on
 // complete event. This one is ineffective if PopCompletedMode ContainerOption
 // is enabled.
@@ -90,17 +174,32 @@ func BarFillerOnComplete(message string) BarOption {
 }
 
 // BarFillerClearOnAbort clears bar's filler on abort event.
-// It's shortcut for BarFillerOnAbort("").
 func BarFillerClearOnAbort() BarOption {
-	return BarFillerOnAbort("")
+	return barFillerOnAbort(func(error) string { return "" })
 }
 
 // BarFillerOnAbort replaces bar's filler with message, on abort event.
+// If the bar is aborted with a cause, e.g. by (*Bar).AbortWithCause or
+// by reader of (*Bar).ProxyReaderHash, the cause is appended to message
+// separated by ": ", or displayed alone if message is empty.
 func BarFillerOnAbort(message string) BarOption {
+	return barFillerOnAbort(func(cause error) string {
+		switch {
+		case cause == nil:
+			return message
+		case message == "":
+			return cause.Error()
+		default:
+			return message + ": " + cause.Error()
+		}
+	})
+}
+
+func barFillerOnAbort(fn func(cause error) string) BarOption {
 	return BarFillerMiddleware(func(base BarFiller) BarFiller {
 		return BarFillerFunc(func(w io.Writer, st decor.Statistics) error {
 			if st.Aborted {
-				_, err := io.WriteString(w, message)
+				_, err := io.WriteString(w, fn(st.Cause))
 				return err
 			}
 			return base.Fill(w, st)
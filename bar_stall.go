@@ -0,0 +1,52 @@
+package mpb
+
+import (
+	"errors"
+	"time"
+)
+
+// ErrStalled is the cause a bar is aborted with, if it makes no progress
+// during timeout set by BarStallAbort.
+var ErrStalled = errors.New("stalled: no progress")
+
+// idle returns time passed since the last progress. Time spent paused or
+// pending isn't counted.
+func (s *bState) idle(now time.Time) time.Duration {
+	if s.paused || s.watched.IsZero() {
+		return 0
+	}
+	return now.Sub(s.watched)
+}
+
+// stalledFor returns idle time if it exceeds timeout set by
+// BarStallTimeout, zero otherwise.
+func (s *bState) stalledFor(now time.Time) time.Duration {
+	if s.stallTimeout <= 0 || s.aborted || s.completed() {
+		return 0
+	}
+	if idle := s.idle(now); idle >= s.stallTimeout {
+		return idle
+	}
+	return 0
+}
+
+// stallAbortTimer returns timer which fires once the bar has been idle
+// for timeout set by BarStallAbort, or nil if there is no such timeout.
+// Expired timer has to be checked with checkStall.
+func (s *bState) stallAbortTimer() *time.Timer {
+	if s.stallAbort <= 0 {
+		return nil
+	}
+	return time.NewTimer(s.stallAbort)
+}
+
+// checkStall aborts the bar if it's been idle long enough, otherwise
+// rearms the timer.
+func (b *Bar) checkStall(s *bState) {
+	idle := s.idle(time.Now())
+	if idle >= s.stallAbort {
+		b.abortState(s, false, ErrStalled)
+		return
+	}
+	s.stallTimer.Reset(s.stallAbort - idle)
+}
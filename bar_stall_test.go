@@ -0,0 +1,61 @@
+package mpb_test
+
+import (
+	"errors"
+	"io"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+)
+
+func TestBarStallAbort(t *testing.T) {
+	p := mpb.New(
+		mpb.WithOutput(io.Discard),
+		mpb.WithAutoRefresh(),
+		mpb.WithRefreshRate(5*time.Millisecond),
+	)
+	var stalled atomic.Bool
+	bar := p.AddBar(10,
+		mpb.BarStallTimeout(10*time.Millisecond),
+		mpb.BarStallAbort(100*time.Millisecond),
+		mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+			if s.Stalled && s.StalledFor >= 10*time.Millisecond {
+				stalled.Store(true)
+			}
+			return ""
+		})),
+	)
+
+	bar.Increment()
+	<-bar.Context().Done()
+	p.Wait()
+
+	if !stalled.Load() {
+		t.Error("Expected bar to be reported as stalled")
+	}
+	if !bar.Aborted() {
+		t.Fatal("Expected bar to be aborted")
+	}
+	if err := bar.Cause(); !errors.Is(err, mpb.ErrStalled) {
+		t.Errorf("Expected cause %v, got: %v", mpb.ErrStalled, err)
+	}
+}
+
+func TestBarStallAbortPaused(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.AddBar(2, mpb.BarStallAbort(20*time.Millisecond))
+
+	bar.Increment()
+	bar.Pause()
+	time.Sleep(60 * time.Millisecond)
+	bar.Resume()
+	bar.Increment()
+	p.Wait()
+
+	if bar.Aborted() {
+		t.Errorf("Expected paused bar not to be aborted, cause: %v", bar.Cause())
+	}
+}
@@ -3,6 +3,7 @@ package mpb_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"fmt"
 	"io"
 	"strings"
@@ -380,3 +381,276 @@ test:
 		t.Errorf("Expected to receive 0 bars, got: %d", barCount)
 	}
 }
+
+func TestBarResetKeepsRemoveOnComplete(t *testing.T) {
+	var barCount int
+	shutdown, depleteHeap := make(chan any), make(chan *mpb.Bar, 1)
+	p := mpb.New(
+		mpb.WithOutput(io.Discard),
+		mpb.WithShutdownNotifier(shutdown),
+		mpb.WithDepleteHeap(depleteHeap),
+		mpb.WithAutoRefresh(),
+	)
+
+	go func(b *mpb.Bar) {
+		b.IncrBy(50)
+		b.Reset(100)
+		b.IncrBy(100)
+		p.Wait()
+	}(p.AddBar(100, mpb.BarRemoveOnComplete()))
+
+test:
+	for {
+		select {
+		case _, ok := <-depleteHeap:
+			if !ok {
+				break test
+			}
+			barCount++
+		case <-shutdown:
+			shutdown = nil
+		case <-time.After(timeout):
+			t.Fatalf("Test timeout %v", timeout)
+		}
+	}
+	if barCount != 0 {
+		t.Errorf("Expected bar to be removed after reset, got: %d bars", barCount)
+	}
+}
+
+func TestBarAbortWithCause(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.AddBar(100)
+	errCause := errors.New("cause")
+
+	bar.AbortWithCause(errCause)
+
+	if !bar.Aborted() {
+		t.Error("expected bar to be aborted")
+	}
+	if err := bar.Cause(); err != errCause {
+		t.Errorf("Expected cause: %v, got: %v", errCause, err)
+	}
+	<-bar.Context().Done()
+	if err := context.Cause(bar.Context()); err != errCause {
+		t.Errorf("Expected context cause: %v, got: %v", errCause, err)
+	}
+
+	p.Wait()
+}
+
+func TestBarTimeout(t *testing.T) {
+	var cause error
+	refresh := make(chan any)
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithManualRefresh(refresh))
+	bar := p.AddBar(100,
+		mpb.BarTimeout(50*time.Millisecond),
+		mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+			if s.Aborted {
+				cause = s.Cause
+			}
+			return ""
+		})),
+	)
+
+	select {
+	case <-bar.Context().Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected bar to be done by timeout")
+	}
+	p.Wait()
+
+	if !bar.Aborted() {
+		t.Error("expected bar to be aborted")
+	}
+	if !errors.Is(cause, context.DeadlineExceeded) {
+		t.Errorf("Expected decorator to see cause: %v, got: %v", context.DeadlineExceeded, cause)
+	}
+}
+
+func TestBarReset(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(80),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+	)
+	bar := p.AddBar(10, mpb.AppendDecorators(decor.Retries("retry %d")))
+	id := bar.ID()
+
+	bar.IncrBy(5)
+	bar.Reset(20)
+
+	if current := bar.Current(); current != 0 {
+		t.Errorf("Expected current: 0, got: %d", current)
+	}
+	if bar.AbortedOrCompleted() {
+		t.Error("Expected bar to be neither aborted nor completed")
+	}
+	bar.IncrBy(10)
+	if bar.Completed() {
+		t.Error("Expected bar not to be completed with new total")
+	}
+	bar.IncrBy(10)
+	p.Wait()
+
+	if !bar.Completed() {
+		t.Error("Expected bar to be completed")
+	}
+	if bar.ID() != id {
+		t.Errorf("Expected ID: %d, got: %d", id, bar.ID())
+	}
+	if !strings.Contains(buf.String(), "retry 1") {
+		t.Errorf("Expected %q in output, got: %q", "retry 1", buf.String())
+	}
+}
+
+func TestBarResetDone(t *testing.T) {
+	p := mpb.New(mpb.WithWidth(80), mpb.WithOutput(io.Discard))
+	bar := p.AddBar(10)
+
+	bar.Abort(false)
+	bar.Reset(20)
+
+	if !bar.Aborted() {
+		t.Error("Expected bar to stay aborted after Reset")
+	}
+	p.Wait()
+}
+
+func TestBarSetMessageAndLabel(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(80),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+	)
+	bar := p.AddBar(10,
+		mpb.PrependDecorators(decor.Label(decor.WCSyncSpaceR)),
+		mpb.AppendDecorators(decor.Message()),
+	)
+
+	bar.SetLabel("label")
+	bar.SetMessage("processing file.txt")
+	bar.IncrBy(10)
+	p.Wait()
+
+	for _, s := range []string{"label", "processing file.txt"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("Expected %q in output, got: %q", s, buf.String())
+		}
+	}
+}
+
+func TestBarSetValue(t *testing.T) {
+	type fileKey struct{}
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(80),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+	)
+	bar := p.AddBar(10,
+		mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+			if file, ok := decor.ValueOf[string](s, fileKey{}); ok {
+				return "file: " + file
+			}
+			return ""
+		})),
+	)
+
+	bar.SetValue(fileKey{}, "a.txt")
+	bar.IncrBy(10)
+	p.Wait()
+
+	if !strings.Contains(buf.String(), "file: a.txt") {
+		t.Errorf("Expected %q in output, got: %q", "file: a.txt", buf.String())
+	}
+}
+
+func TestBarTimings(t *testing.T) {
+	var stat decor.Statistics
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithAutoRefresh())
+	bar := p.AddBar(2,
+		mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+			stat = s
+			return ""
+		})),
+	)
+
+	time.Sleep(10 * time.Millisecond)
+	bar.Increment()
+	time.Sleep(10 * time.Millisecond)
+	bar.Increment()
+	p.Wait()
+
+	if d := stat.Started.Sub(stat.Created); d < 10*time.Millisecond {
+		t.Errorf("Expected Started to be at least 10ms after Created, got: %s", d)
+	}
+	if d := stat.Updated.Sub(stat.Started); d < 10*time.Millisecond {
+		t.Errorf("Expected Updated to be at least 10ms after Started, got: %s", d)
+	}
+	if stat.Finished.Before(stat.Updated) {
+		t.Error("Expected Finished not to be before Updated")
+	}
+}
+
+func TestBarPauseResume(t *testing.T) {
+	var stat decor.Statistics
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithAutoRefresh())
+	bar := p.AddBar(2,
+		mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+			stat = s
+			return ""
+		})),
+	)
+
+	bar.Increment()
+	bar.Pause()
+	time.Sleep(20 * time.Millisecond)
+	bar.Resume()
+	bar.Increment()
+	p.Wait()
+
+	if stat.Paused {
+		t.Error("Expected bar not to be paused")
+	}
+	if stat.PausedFor < 20*time.Millisecond {
+		t.Errorf("Expected PausedFor to be at least 20ms, got: %s", stat.PausedFor)
+	}
+	if active := stat.Updated.Sub(stat.Started); stat.PausedFor > active {
+		t.Errorf("Expected PausedFor %s not to exceed active time %s", stat.PausedFor, active)
+	}
+}
+
+func TestBarScale(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(80),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+	)
+	bar := p.AddBar(1000,
+		mpb.BarScale(10),
+		mpb.AppendDecorators(decor.CountersNoUnit("%.1f / %.1f")),
+	)
+
+	bar.SetCurrentFloat(12.5)
+	if current := bar.Current(); current != 125 {
+		t.Errorf("Expected current: 125, got: %d", current)
+	}
+	bar.IncrFloat(0.25)
+	if current := bar.CurrentFloat(); current != 12.8 {
+		t.Errorf("Expected current: 12.8, got: %v", current)
+	}
+	bar.SetTotalFloat(-1, true)
+	p.Wait()
+
+	if !strings.Contains(buf.String(), "12.8 / 12.8") {
+		t.Errorf("Expected %q in output, got: %q", "12.8 / 12.8", buf.String())
+	}
+}
@@ -29,6 +29,57 @@ func WithWaitGroup(wg *sync.WaitGroup) ContainerOption {
 	}
 }
 
+// WithTaskLimit limits number of tasks started by (*Progress).Go which
+// may run at the same time. Task slots are separate from the ones of
+// WithMaxActiveBars, which doesn't limit tasks once this option is set.
+// Zero or negative value means no limit.
+func WithTaskLimit(n int) ContainerOption {
+	return func(s *pState) {
+		s.taskLimit = n
+	}
+}
+
+// WithFailFast cancels context of all tasks started by (*Progress).Go as
+// soon as any of them fails. Tasks which haven't started yet are not run.
+func WithFailFast() ContainerOption {
+	return func(s *pState) {
+		s.failFast = true
+	}
+}
+
+// WithMaxActiveBars limits number of active bars. Bars added beyond the
+// limit are pending, they become active in order of addition as soon as
+// any active bar completes or aborts. While bar is pending it's not
+// rendered by default, but its state could be changed as usual. Pending
+// bar which is aborted or completed gives up its place in the queue and
+// is rendered for the last time. Bars constructed with BarQueueAfter
+// option aren't counted, neither are bars of tasks limited by
+// WithTaskLimit. Zero or negative value means no limit.
+func WithMaxActiveBars(n int) ContainerOption {
+	return func(s *pState) {
+		s.maxActive = n
+	}
+}
+
+// WithPendingMeta renders pending bars, see WithMaxActiveBars, applying
+// fn to each of their rows. Primary usage intention is to render pending
+// bars dimmed by SGR display attributes. Decorators and fillers of pending
+// bar see decor.Statistics.Pending set.
+func WithPendingMeta(fn func(string) string) ContainerOption {
+	return func(s *pState) {
+		s.pendingMeta = fn
+	}
+}
+
+// WithPendingSummary renders a single row at the bottom, with number of
+// pending bars formatted by fn, see WithMaxActiveBars. The row isn't
+// rendered if there are no pending bars.
+func WithPendingSummary(fn func(count int) string) ContainerOption {
+	return func(s *pState) {
+		s.pendingSummary = fn
+	}
+}
+
 // WithWidth sets container width. If not set it defaults to terminal
 // width. A bar added to the container will inherit its width, unless
 // overridden by `func BarWidth(int) BarOption`.
@@ -47,6 +98,19 @@ func WithQueueLen(len int) ContainerOption {
 	}
 }
 
+// WithRateLimit sets throughput limit in bytes per second, which is shared
+// among all bars of the container. Once set, proxies constructed by
+// (*Bar).ProxyReader and (*Bar).ProxyWriter are throttled, so that their
+// combined throughput doesn't exceed the limit. Bandwidth is split evenly
+// among bars which are being proxied at the moment, share of a bar is
+// given back once its proxy reaches EOF or the bar is done. Limit can be
+// adjusted at runtime with (*Progress).SetRateLimit.
+func WithRateLimit(bytesPerSec int64) ContainerOption {
+	return func(s *pState) {
+		s.rateLimit = bytesPerSec
+	}
+}
+
 // WithRefreshRate overrides default 150ms refresh rate.
 func WithRefreshRate(d time.Duration) ContainerOption {
 	return func(s *pState) {
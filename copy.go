@@ -0,0 +1,84 @@
+package mpb
+
+import (
+	"io"
+	"net/http"
+	"os"
+)
+
+// Copy copies from src to dst until either EOF is reached on src or an
+// error occurs, same as io.Copy does, tracking progress with a new bar.
+// Bar's total is inferred from src if it's one of:
+//
+//	*os.File
+//	*io.LimitedReader
+//	*io.SectionReader
+//	interface{ Len() int } like *bytes.Reader, *bytes.Buffer or *strings.Reader
+//
+// If total can't be inferred bar is constructed with spinner filler. Bar
+// is completed on success and is aborted with copy error as its cause
+// otherwise. Returns (0, ErrDone[*Progress]) if called after (*Progress).Wait.
+func (p *Progress) Copy(dst io.Writer, src io.Reader, options ...BarOption) (int64, error) {
+	return p.copy(dst, src, sizeOf(src), options...)
+}
+
+// CopyResponse is same as Copy, but copies from response's body with
+// total inferred from its ContentLength. Response's body isn't closed.
+func (p *Progress) CopyResponse(dst io.Writer, resp *http.Response, options ...BarOption) (int64, error) {
+	return p.copy(dst, resp.Body, resp.ContentLength, options...)
+}
+
+func (p *Progress) copy(dst io.Writer, src io.Reader, size int64, options ...BarOption) (int64, error) {
+	var builder BarFillerBuilder = barStyleComposer
+	if size < 0 {
+		builder, size = spinnerStyleComposer, 0
+	}
+	bar, err := p.Add(size, builder.Build(), options...)
+	if err != nil {
+		return 0, err
+	}
+	// ProxyReader's Close isn't called, src is owned by the caller
+	pr, err := bar.ProxyReader(src)
+	if err != nil {
+		return 0, err
+	}
+	n, err := io.Copy(dst, pr)
+	if err != nil {
+		bar.abort(false, err)
+		return n, err
+	}
+	// total might have been inaccurate or unknown at all
+	bar.SetTotal(-1, true)
+	return n, nil
+}
+
+// sizeOf returns number of bytes left to read, or -1 if unknown.
+func sizeOf(r io.Reader) int64 {
+	switch r := r.(type) {
+	case *os.File:
+		fi, err := r.Stat()
+		if err != nil || !fi.Mode().IsRegular() {
+			return -1
+		}
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return fi.Size()
+		}
+		return max(fi.Size()-offset, 0)
+	case *io.LimitedReader:
+		if size := sizeOf(r.R); size >= 0 {
+			return max(min(size, r.N), 0)
+		}
+		return max(r.N, 0)
+	case *io.SectionReader:
+		offset, err := r.Seek(0, io.SeekCurrent)
+		if err != nil {
+			return -1
+		}
+		return max(r.Size()-offset, 0)
+	case interface{ Len() int }:
+		return int64(r.Len())
+	default:
+		return -1
+	}
+}
@@ -25,7 +25,7 @@ func copyBuffer(bar *Bar, dst io.Writer, src io.Reader, buf []byte) (written int
 	}
 	for {
 		start := time.Now()
-		nr, er := src.Read(buf)
+		nr, er := bar.limitedRead(src, buf)
 		if nr > 0 {
 			nw, ew := dst.Write(buf[0:nr])
 			if nw < 0 || nr < nw {
@@ -0,0 +1,130 @@
+package mpb_test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+)
+
+type errReader struct{}
+
+func (errReader) Read([]byte) (int, error) {
+	return 0, errors.New("read failure")
+}
+
+func TestCopy(t *testing.T) {
+	file := filepath.Join(t.TempDir(), "content")
+	err := os.WriteFile(file, []byte(content), 0o600)
+	if err != nil {
+		t.Fatal(err)
+	}
+	f, err := os.Open(file)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer func() {
+		_ = f.Close()
+	}()
+	_, err = f.Seek(10, io.SeekStart)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		name     string
+		src      io.Reader
+		expected int
+	}{
+		{"bytes.Reader", bytes.NewReader([]byte(content)), len(content)},
+		{"strings.Reader", strings.NewReader(content), len(content)},
+		{"io.LimitedReader", io.LimitReader(strings.NewReader(content), 20), 20},
+		{"io.SectionReader", io.NewSectionReader(strings.NewReader(content), 5, 10), 10},
+		{"os.File", f, len(content) - 10},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			refresh := make(chan any)
+			p := mpb.New(
+				mpb.WithWidth(80),
+				mpb.WithOutput(&buf),
+				mpb.WithManualRefresh(refresh),
+			)
+			var dst bytes.Buffer
+			n, err := p.Copy(&dst, tc.src,
+				mpb.AppendDecorators(decor.CountersNoUnit("%d/%d")),
+			)
+			if err != nil {
+				t.Fatal(err)
+			}
+			p.Wait()
+			if n != int64(tc.expected) {
+				t.Errorf("Expected copied: %d, got: %d", tc.expected, n)
+			}
+			counters := fmt.Sprintf("%d/%[1]d", tc.expected)
+			if !strings.Contains(buf.String(), counters) {
+				t.Errorf("Expected %q in output, got: %q", counters, buf.String())
+			}
+		})
+	}
+}
+
+func TestCopyUnknownSize(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	tr := &testReader{strings.NewReader(content), false}
+	n, err := p.Copy(io.Discard, tr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != int64(len(content)) {
+		t.Errorf("Expected copied: %d, got: %d", len(content), n)
+	}
+	p.Wait()
+}
+
+func TestCopyResponse(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	resp := &http.Response{
+		ContentLength: int64(len(content)),
+		Body:          io.NopCloser(strings.NewReader(content)),
+	}
+	var dst bytes.Buffer
+	_, err := p.CopyResponse(&dst, resp)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := dst.String(); got != content {
+		t.Errorf("Expected content: %s, got: %s\n", content, got)
+	}
+	p.Wait()
+}
+
+func TestCopyError(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(80),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+	)
+	_, err := p.Copy(io.Discard, errReader{},
+		mpb.BarFillerOnAbort(""),
+	)
+	if err == nil {
+		t.Fatal("Expected copy error")
+	}
+	p.Wait()
+	if !strings.Contains(buf.String(), "read failure") {
+		t.Errorf("Expected abort cause in output, got: %q", buf.String())
+	}
+}
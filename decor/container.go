@@ -0,0 +1,126 @@
+package decor
+
+import (
+	"cmp"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/vbauerster/mpb/v8/internal"
+)
+
+// ContainerStatistics consists of statistics aggregated over all bars of
+// the container, including bars which have been removed or popped out.
+// It's aggregated on each render, so decorators see the state as of the
+// previous render. Total includes bars which are queued, with total they
+// have been queued with or set while pending. Values of bars with
+// different mpb.BarScale are converted to the largest scale.
+type ContainerStatistics struct {
+	Total     int64     // sum of bars' Total
+	Current   int64     // sum of bars' Current
+	Scale     int64     // fixed-point scale of Total and Current, the largest mpb.BarScale
+	Active    int       // number of bars which are neither queued, completed nor aborted
+	Queued    int       // number of bars waiting for a slot, for prerequisites or in queue
+	Completed int       // number of completed bars
+	Aborted   int       // number of aborted bars
+	Updated   time.Time // when statistics were aggregated
+}
+
+// ContainerSpeed decorator displays combined speed of all bars of the
+// container. Estimator is sampled with ContainerStatistics on each render.
+//
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`estimator` e.g. one of [NewWindowEstimator|NewRegressionEstimator|NewBlendEstimator|NewRingEstimator]
+//
+//	`wcc` optional WC config
+func ContainerSpeed(unit any, format string, estimator Estimator, wcc ...WC) Decorator {
+	producer := chooseSpeedProducer(unit, format)
+	estimator = NewThreadSafeEstimator(estimator)
+	fn := func(s Statistics) string {
+		speed, _ := sampleContainer(estimator, s.Container)
+		if cs := s.Container; cs != nil && cs.Scale > 1 {
+			speed /= float64(cs.Scale)
+		}
+		return producer(speed)
+	}
+	return Any(fn, wcc...)
+}
+
+// ContainerETA decorator displays combined ETA of all bars of the
+// container. Estimator is sampled with ContainerStatistics on each render.
+//
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
+//
+//	`estimator` e.g. one of [NewWindowEstimator|NewRegressionEstimator|NewBlendEstimator|NewRingEstimator]
+//
+//	`wcc` optional WC config
+func ContainerETA(style TimeFormatter, estimator Estimator, wcc ...WC) Decorator {
+	producer := chooseTimeProducer(style)
+	estimator = NewThreadSafeEstimator(estimator)
+	fn := func(s Statistics) string {
+		var remaining time.Duration
+		speed, ok := sampleContainer(estimator, s.Container)
+		if ok && speed > 0 {
+			seconds := float64(s.Container.Total-s.Container.Current) / speed
+			remaining = time.Duration(math.Round(seconds * 1e9))
+		}
+		return producer(remaining)
+	}
+	return Any(fn, wcc...)
+}
+
+func sampleContainer(e Estimator, cs *ContainerStatistics) (float64, bool) {
+	if cs == nil {
+		return 0, false
+	}
+	e.Sample(cs.Current, cs.Updated)
+	return e.Rate(cs.Updated)
+}
+
+// ContainerPercentage decorator displays overall percentage of all bars
+// of the container, i.e. weighted by their totals.
+//
+//	`format` printf compatible verb, see NewPercentage
+//
+//	`wcc` optional WC config
+func ContainerPercentage(format string, wcc ...WC) Decorator {
+	if format == "" {
+		format = "% d"
+	}
+	fn := func(s Statistics) string {
+		var p float64
+		if cs := s.Container; cs != nil {
+			p = internal.Percentage(cs.Total, cs.Current, 100)
+		}
+		return fmt.Sprintf(format, percentageType(p))
+	}
+	return Any(fn, wcc...)
+}
+
+// ContainerCounts decorator displays number of active, queued, completed
+// and aborted bars of the container, in that order.
+//
+//	`format` printf compatible format with four %d verbs, if empty
+//	"active: %d, queued: %d, completed: %d, aborted: %d" is used
+//
+//	`wcc` optional WC config
+func ContainerCounts(format string, wcc ...WC) Decorator {
+	fn := func(s Statistics) string {
+		var cs ContainerStatistics
+		if s.Container != nil {
+			cs = *s.Container
+		}
+		// translated at render time, as locale could be set later
+		format := cmp.Or(format, Translate("active: %d, queued: %d, completed: %d, aborted: %d"))
+		return fmt.Sprintf(format,
+			fixedPoint{int64(cs.Active), 1},
+			fixedPoint{int64(cs.Queued), 1},
+			fixedPoint{int64(cs.Completed), 1},
+			fixedPoint{int64(cs.Aborted), 1},
+		)
+	}
+	return Any(fn, wcc...)
+}
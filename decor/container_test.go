@@ -0,0 +1,49 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestContainerDecorators(t *testing.T) {
+	speed := ContainerSpeed(0, "%.0f", NewRingEstimator(2))
+	eta := ContainerETA(ET_STYLE_GO, NewRingEstimator(2))
+	percentage := ContainerPercentage("%d")
+	counts := ContainerCounts("")
+
+	// nil until aggregated
+	for _, d := range []Decorator{speed, eta, percentage, counts} {
+		d.Decor(Statistics{})
+	}
+
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	cs := &ContainerStatistics{Total: 300, Current: 100, Updated: t0}
+	speed.Decor(Statistics{Container: cs})
+	eta.Decor(Statistics{Container: cs})
+
+	cs = &ContainerStatistics{
+		Total:     300,
+		Current:   150,
+		Active:    2,
+		Queued:    3,
+		Completed: 1,
+		Updated:   t0.Add(5 * time.Second),
+	}
+	stat := Statistics{Container: cs}
+	cases := map[string]struct {
+		d        Decorator
+		expected string
+	}{
+		"speed":      {speed, "10"},
+		"eta":        {eta, "15s"},
+		"percentage": {percentage, "50%"},
+		"counts":     {counts, "active: 2, queued: 3, completed: 1, aborted: 0"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got, _ := tc.d.Decor(stat); got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
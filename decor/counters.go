@@ -2,8 +2,49 @@ package decor
 
 import (
 	"fmt"
+	"math"
+	"strconv"
 )
 
+var _ fmt.Formatter = fixedPoint{}
+
+// fixedPoint formats scaled value, set by mpb.BarScale, so float verbs
+// display fractional part and integer verbs display rounded value. Value
+// of scale 1 is formatted as plain integer. Separators of global locale
+// are applied to %d, %v and %f verbs.
+type fixedPoint struct {
+	value, scale int64
+}
+
+func (v fixedPoint) Format(f fmt.State, verb rune) {
+	if v.scale <= 1 {
+		v.scale = 1
+	}
+	fv := float64(v.value) / float64(v.scale)
+	switch verb {
+	case 'f', 'F', 'e', 'E', 'g', 'G':
+		if verb == 'f' && localizesNumbers() {
+			prec, ok := f.Precision()
+			if !ok {
+				prec = 6 // default prec of fmt.Printf("%f")
+			}
+			writeNumber(f, strconv.AppendFloat(make([]byte, 0, 24), fv, 'f', prec, 64))
+			return
+		}
+		fmt.Fprintf(f, fmt.FormatString(f, verb), fv)
+		return
+	}
+	n := v.value
+	if v.scale != 1 {
+		n, verb = int64(math.Round(fv)), 'd'
+	}
+	if (verb == 'd' || verb == 'v') && localizesNumbers() {
+		writeNumber(f, strconv.AppendInt(make([]byte, 0, 24), n, 10))
+		return
+	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), n)
+}
+
 // CountersNoUnit is a wrapper around Counters with no unit param.
 func CountersNoUnit(pairFmt string, wcc ...WC) Decorator {
 	return Counters(0, pairFmt, wcc...)
@@ -23,7 +64,7 @@ func CountersKiloByte(pairFmt string, wcc ...WC) Decorator {
 
 // Counters decorator with dynamic unit measure adjustment.
 //
-//	`unit` one of [0|SizeB1024(0)|SizeB1000(0)]
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
 //
 //	`pairFmt` printf compatible verbs for current and total
 //
@@ -37,31 +78,25 @@ func CountersKiloByte(pairFmt string, wcc ...WC) Decorator {
 //	pairFmt="% .1f / % .1f" output: "1.0 MB / 12.0 MB"
 //	pairFmt="%f / %f"       output: "1.000000MB / 12.000000MB"
 //	pairFmt="% f / % f"     output: "1.000000 MB / 12.000000 MB"
+//
+// If unit=0 and bar has scale set by mpb.BarScale, values are divided by
+// scale, so pairFmt="%.1f / %.1f" outputs fractional values like "12.5 / 100.0".
 func Counters(unit any, pairFmt string, wcc ...WC) Decorator {
 	producer := func() DecorFunc {
-		switch unit.(type) {
-		case SizeB1024:
-			if pairFmt == "" {
-				pairFmt = "% d / % d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(pairFmt, SizeB1024(s.Current), SizeB1024(s.Total))
-			}
-		case SizeB1000:
+		if unit, ok := unit.(Unit); ok {
 			if pairFmt == "" {
 				pairFmt = "% d / % d"
 			}
 			return func(s Statistics) string {
-				return fmt.Sprintf(pairFmt, SizeB1000(s.Current), SizeB1000(s.Total))
-			}
-		default:
-			if pairFmt == "" {
-				pairFmt = "%d / %d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(pairFmt, s.Current, s.Total)
+				return fmt.Sprintf(pairFmt, unitValue{unit, s.CurrentFloat()}, unitValue{unit, s.TotalFloat()})
 			}
 		}
+		if pairFmt == "" {
+			pairFmt = "%d / %d"
+		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(pairFmt, fixedPoint{s.Current, s.Scale}, fixedPoint{s.Total, s.Scale})
+		}
 	}
 	return Any(producer(), wcc...)
 }
@@ -85,7 +120,7 @@ func TotalKiloByte(format string, wcc ...WC) Decorator {
 
 // Total decorator with dynamic unit measure adjustment.
 //
-//	`unit` one of [0|SizeB1024(0)|SizeB1000(0)]
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
 //
 //	`format` printf compatible verb for Total
 //
@@ -101,29 +136,20 @@ func TotalKiloByte(format string, wcc ...WC) Decorator {
 //	format="% f"   output: "12.000000 MiB"
 func Total(unit any, format string, wcc ...WC) Decorator {
 	producer := func() DecorFunc {
-		switch unit.(type) {
-		case SizeB1024:
-			if format == "" {
-				format = "% d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1024(s.Total))
-			}
-		case SizeB1000:
+		if unit, ok := unit.(Unit); ok {
 			if format == "" {
 				format = "% d"
 			}
 			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1000(s.Total))
-			}
-		default:
-			if format == "" {
-				format = "%d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, s.Total)
+				return fmt.Sprintf(format, unitValue{unit, s.TotalFloat()})
 			}
 		}
+		if format == "" {
+			format = "%d"
+		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(format, fixedPoint{s.Total, s.Scale})
+		}
 	}
 	return Any(producer(), wcc...)
 }
@@ -147,7 +173,7 @@ func CurrentKiloByte(format string, wcc ...WC) Decorator {
 
 // Current decorator with dynamic unit measure adjustment.
 //
-//	`unit` one of [0|SizeB1024(0)|SizeB1000(0)]
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
 //
 //	`format` printf compatible verb for Current
 //
@@ -163,29 +189,20 @@ func CurrentKiloByte(format string, wcc ...WC) Decorator {
 //	format="% f"   output: "12.000000 MiB"
 func Current(unit any, format string, wcc ...WC) Decorator {
 	producer := func() DecorFunc {
-		switch unit.(type) {
-		case SizeB1024:
-			if format == "" {
-				format = "% d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1024(s.Current))
-			}
-		case SizeB1000:
+		if unit, ok := unit.(Unit); ok {
 			if format == "" {
 				format = "% d"
 			}
 			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1000(s.Current))
-			}
-		default:
-			if format == "" {
-				format = "%d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, s.Current)
+				return fmt.Sprintf(format, unitValue{unit, s.CurrentFloat()})
 			}
 		}
+		if format == "" {
+			format = "%d"
+		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(format, fixedPoint{s.Current, s.Scale})
+		}
 	}
 	return Any(producer(), wcc...)
 }
@@ -209,7 +226,7 @@ func InvertedCurrentKiloByte(format string, wcc ...WC) Decorator {
 
 // InvertedCurrent decorator with dynamic unit measure adjustment.
 //
-//	`unit` one of [0|SizeB1024(0)|SizeB1000(0)]
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
 //
 //	`format` printf compatible verb for InvertedCurrent
 //
@@ -225,29 +242,20 @@ func InvertedCurrentKiloByte(format string, wcc ...WC) Decorator {
 //	format="% f"   output: "12.000000 MiB"
 func InvertedCurrent(unit any, format string, wcc ...WC) Decorator {
 	producer := func() DecorFunc {
-		switch unit.(type) {
-		case SizeB1024:
-			if format == "" {
-				format = "% d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1024(s.Total-s.Current))
-			}
-		case SizeB1000:
+		if unit, ok := unit.(Unit); ok {
 			if format == "" {
 				format = "% d"
 			}
 			return func(s Statistics) string {
-				return fmt.Sprintf(format, SizeB1000(s.Total-s.Current))
-			}
-		default:
-			if format == "" {
-				format = "%d"
-			}
-			return func(s Statistics) string {
-				return fmt.Sprintf(format, s.Total-s.Current)
+				return fmt.Sprintf(format, unitValue{unit, s.TotalFloat() - s.CurrentFloat()})
 			}
 		}
+		if format == "" {
+			format = "%d"
+		}
+		return func(s Statistics) string {
+			return fmt.Sprintf(format, fixedPoint{s.Total - s.Current, s.Scale})
+		}
 	}
 	return Any(producer(), wcc...)
 }
@@ -0,0 +1,26 @@
+package decor
+
+import "testing"
+
+func TestCountersScale(t *testing.T) {
+	cases := map[string]struct {
+		pairFmt  string
+		scale    int64
+		expected string
+	}{
+		"no scale %d":   {"%d / %d", 1, "125 / 1000"},
+		"scale %d":      {"%d / %d", 10, "13 / 100"},
+		"scale %.1f":    {"%.1f / %.1f", 10, "12.5 / 100.0"},
+		"scale %5.2f":   {"%5.2f / %.0f", 10, "12.50 / 100"},
+		"zero scale %d": {"%d / %d", 0, "125 / 1000"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := CountersNoUnit(tc.pairFmt)
+			got, _ := d.Decor(Statistics{Current: 125, Total: 1000, Scale: tc.scale})
+			if got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
@@ -31,15 +31,19 @@ const (
 	DSyncSpaceR = DSyncWidth | DextraSpace | DindentRight
 )
 
-// TimeStyle enum.
+// TimeStyle enum, which implements TimeFormatter interface.
 type TimeStyle int
 
 // TimeStyle kinds.
 const (
-	ET_STYLE_GO TimeStyle = iota
-	ET_STYLE_HHMMSS
-	ET_STYLE_HHMM
-	ET_STYLE_MMSS
+	ET_STYLE_GO      TimeStyle = iota // 1h2m3s
+	ET_STYLE_HHMMSS                   // 01:02:03
+	ET_STYLE_HHMM                     // 01:02
+	ET_STYLE_MMSS                     // 02:03, 01:02:03 if there are hours
+	ET_STYLE_DHHMMSS                  // 1d 02:03:04, 02:03:04 if there are no days
+	ET_STYLE_COMPACT                  // 1d2h3m4s with zero units omitted
+	ET_STYLE_MILLIS                   // 01:02:03.456
+	ET_STYLE_HUMAN                    // about 5 minutes
 )
 
 // Statistics contains fields which are necessary for implementing
@@ -51,8 +55,78 @@ type Statistics struct {
 	Total          int64
 	Current        int64
 	Refill         int64
+	Scale          int64 // fixed-point scale set by mpb.BarScale, 1 by default
 	Completed      bool
 	Aborted        bool
+	Skipped        bool                 // aborted due to unsatisfied mpb.BarAfter prerequisites
+	Pending        bool                 // waits for a slot or for mpb.BarAfter prerequisites
+	Cause          error                // abort cause if any
+	RateLimit      int64                // bytes per second, zero if not limited
+	Retries        int                  // number of (*mpb.Bar).Reset calls
+	Message        string               // set by (*mpb.Bar).SetMessage
+	Label          string               // set by (*mpb.Bar).SetLabel
+	Values         map[any]any          // set by (*mpb.Bar).SetValue, must not be modified
+	Created        time.Time            // when bar was constructed
+	Started        time.Time            // when bar's current was updated first, zero if not yet
+	Updated        time.Time            // when bar's current was updated last, zero if not yet
+	Finished       time.Time            // when bar was completed or aborted, zero if not yet
+	Paused         bool                 // set by (*mpb.Bar).Pause
+	PausedFor      time.Duration        // time spent paused since Started
+	Stalled        bool                 // no progress for timeout set by mpb.BarStallTimeout
+	StalledFor     time.Duration        // time since the last progress while Stalled
+	Container      *ContainerStatistics // aggregated over all bars, nil until aggregated
+}
+
+// Value returns value attached to the bar under provided key, or nil if
+// there is no such key.
+func (s Statistics) Value(key any) any {
+	return s.Values[key]
+}
+
+// TotalFloat returns Total divided by Scale.
+func (s Statistics) TotalFloat() float64 {
+	return float64(s.Total) / float64(max(s.Scale, 1))
+}
+
+// CurrentFloat returns Current divided by Scale.
+func (s Statistics) CurrentFloat() float64 {
+	return float64(s.Current) / float64(max(s.Scale, 1))
+}
+
+// unscale converts per unit value into per 1.0 of scaled value.
+func (s Statistics) unscale(v float64) float64 {
+	if s.Scale > 1 {
+		return v / float64(s.Scale)
+	}
+	return v
+}
+
+// activeSince returns duration passed since start, leaving out time
+// spent paused.
+func (s Statistics) activeSince(start time.Time) time.Duration {
+	return max(time.Since(start)-s.PausedFor, 0)
+}
+
+// activeTime shifts t back by time spent paused, so time of estimator's
+// samples has no gaps of pause.
+func (s Statistics) activeTime(t time.Time) time.Time {
+	return t.Add(-s.PausedFor)
+}
+
+// stalled returns warning displayed by built-in speed and ETA decorators
+// instead of their value, while the bar is stalled.
+func (s Statistics) stalled() (string, bool) {
+	if !s.Stalled {
+		return "", false
+	}
+	return Translate("stalled") + " " + formatCompact(s.StalledFor), true
+}
+
+// ValueOf is typed version of Statistics.Value. Reports false if there is
+// no value under provided key or it isn't of type T.
+func ValueOf[T any](s Statistics, key any) (T, bool) {
+	v, ok := s.Values[key].(T)
+	return v, ok
 }
 
 // Decorator interface.
@@ -122,6 +196,13 @@ type ShutdownListener interface {
 	OnShutdown()
 }
 
+// ResetListener interface.
+// If decorator keeps state which has to be restarted, when bar is reset
+// by (*Bar).Reset, this is the interface to implement.
+type ResetListener interface {
+	OnReset()
+}
+
 // Global convenience instances of WC with sync width bit set.
 // To be used with multiple bars only, i.e. not effective for single bar usage.
 var (
@@ -187,3 +268,18 @@ func initWC(wcc ...WC) WC {
 	}
 	return wc.Init()
 }
+
+// unwrapAs unwraps d until it's of type T.
+func unwrapAs[T any](d Decorator) (T, bool) {
+	for {
+		if t, ok := d.(T); ok {
+			return t, true
+		}
+		w, ok := d.(Wrapper)
+		if !ok {
+			var zero T
+			return zero, false
+		}
+		d = w.Unwrap()
+	}
+}
@@ -0,0 +1,43 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestStatisticsValue(t *testing.T) {
+	type key struct{}
+	s := Statistics{Values: map[any]any{key{}: 42}}
+
+	if v := s.Value(key{}); v != 42 {
+		t.Errorf("Expected value: 42, got: %v", v)
+	}
+	if v := s.Value("missing"); v != nil {
+		t.Errorf("Expected nil value, got: %v", v)
+	}
+	if v, ok := ValueOf[int](s, key{}); !ok || v != 42 {
+		t.Errorf("Expected (42, true), got: (%v, %t)", v, ok)
+	}
+	if _, ok := ValueOf[string](s, key{}); ok {
+		t.Error("Expected false for value of another type")
+	}
+}
+
+func TestElapsedSinceStarted(t *testing.T) {
+	d := Elapsed(ET_STYLE_GO)
+	if str, _ := d.Decor(Statistics{}); str != "0s" {
+		t.Errorf("Expected: %q before start, got: %q", "0s", str)
+	}
+	started := time.Now().Add(-2 * time.Second)
+	if str, _ := d.Decor(Statistics{Started: started}); str != "2s" {
+		t.Errorf("Expected: %q, got: %q", "2s", str)
+	}
+}
+
+func TestElapsedExcludesPaused(t *testing.T) {
+	d := Elapsed(ET_STYLE_GO)
+	started := time.Now().Add(-3 * time.Second)
+	if str, _ := d.Decor(Statistics{Started: started, PausedFor: time.Second}); str != "2s" {
+		t.Errorf("Expected: %q, got: %q", "2s", str)
+	}
+}
@@ -1,33 +1,62 @@
 package decor
 
 import (
+	"cmp"
 	"time"
 )
 
-// Elapsed decorator. It's wrapper of NewElapsed.
+var (
+	_ Decorator     = (*elapsed)(nil)
+	_ ResetListener = (*elapsed)(nil)
+)
+
+// Elapsed decorator. It's wrapper of NewElapsed with zero start time,
+// i.e. elapsed time is measured since the first update of bar's current.
 //
-//	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
 //
 //	`wcc` optional WC config
-func Elapsed(style TimeStyle, wcc ...WC) Decorator {
-	return NewElapsed(style, time.Now(), wcc...)
+func Elapsed(style TimeFormatter, wcc ...WC) Decorator {
+	return NewElapsed(style, time.Time{}, wcc...)
 }
 
 // NewElapsed returns elapsed time decorator.
 //
-//	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
 //
-//	`start` start time
+//	`start` start time, if zero decor.Statistics.Started is used
 //
 //	`wcc` optional WC config
-func NewElapsed(style TimeStyle, start time.Time, wcc ...WC) Decorator {
-	var msg string
-	producer := chooseTimeProducer(style)
-	fn := func(s Statistics) string {
-		if !s.Completed && !s.Aborted {
-			msg = producer(time.Since(start))
+func NewElapsed(style TimeFormatter, start time.Time, wcc ...WC) Decorator {
+	d := &elapsed{
+		WC:       initWC(wcc...),
+		start:    start,
+		producer: chooseTimeProducer(style),
+	}
+	return d
+}
+
+type elapsed struct {
+	WC
+	start    time.Time
+	producer func(time.Duration) string
+	msg      string
+}
+
+func (d *elapsed) Decor(s Statistics) (string, int) {
+	if !s.Completed && !s.Aborted {
+		var elapsed time.Duration
+		if start := cmp.Or(d.start, s.Started); !start.IsZero() {
+			elapsed = s.activeSince(start)
 		}
-		return msg
+		d.msg = d.producer(elapsed)
+	}
+	return d.Format(d.msg)
+}
+
+func (d *elapsed) OnReset() {
+	// zero start is restarted by the bar itself
+	if !d.start.IsZero() {
+		d.start = time.Now()
 	}
-	return Any(fn, wcc...)
 }
@@ -0,0 +1,314 @@
+package decor
+
+import (
+	"errors"
+	"sync"
+	"time"
+)
+
+var (
+	_ Estimator = (*threadSafeEstimator)(nil)
+	_ Estimator = (*windowEstimator)(nil)
+	_ Estimator = (*regressionEstimator)(nil)
+	_ Estimator = (*blendEstimator)(nil)
+	_ Estimator = (*ringEstimator)(nil)
+)
+
+// Estimator interface. Implementations estimate rate of progress, given
+// samples of bar's current over time. Estimator could be shared among
+// EstimatedSpeed and EstimatedETA decorators of the same bar, duplicate
+// samples are expected to be ignored. Built-in estimators are thread safe,
+// custom one is converted by NewThreadSafeEstimator on decorator's
+// construction, so if it's shared, convert it once beforehand. Time spent
+// paused is left out of sample's time, so pause isn't seen as a gap.
+type Estimator interface {
+	// Sample records bar's current value at time t. Samples are
+	// provided in chronological order, sample which isn't later than
+	// the last one should be ignored.
+	Sample(current int64, t time.Time)
+	// Rate returns estimated progress per second at time now. Reports
+	// false if there isn't enough samples yet.
+	Rate(now time.Time) (rate float64, ok bool)
+	// Reset drops all samples.
+	Reset()
+}
+
+type threadSafeEstimator struct {
+	Estimator
+	mu sync.Mutex
+}
+
+func (e *threadSafeEstimator) Sample(current int64, t time.Time) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.Estimator.Sample(current, t)
+}
+
+func (e *threadSafeEstimator) Rate(now time.Time) (float64, bool) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return e.Estimator.Rate(now)
+}
+
+func (e *threadSafeEstimator) Reset() {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.Estimator.Reset()
+}
+
+// NewThreadSafeEstimator converts provided Estimator into thread safe
+// Estimator.
+func NewThreadSafeEstimator(estimator Estimator) Estimator {
+	if tse, ok := estimator.(*threadSafeEstimator); ok {
+		return tse
+	}
+	return &threadSafeEstimator{Estimator: estimator}
+}
+
+// WithEstimator returns decorator which is displayed same way as provided
+// built-in ETA or speed decorator, i.e. with its style or unit and format,
+// normalizer and WC config, but which relies on Estimator implementation
+// to calculate its rate, see EstimatedETA and EstimatedSpeed. Provided
+// decorator is replaced, so wrap returned one with OnComplete and alike,
+// not the other way around. In case of WindowedETA and WindowedSpeed
+// estimator is sampled on each render, as theirs is.
+//
+//	`d` one of [AverageETA|NewAverageETA|EwmaETA|MovingAverageETA|EstimatedETA|WindowedETA|AverageSpeed|NewAverageSpeed|EwmaSpeed|MovingAverageSpeed|EstimatedSpeed|WindowedSpeed] decorators
+//
+//	`estimator` e.g. one of [NewWindowEstimator|NewRegressionEstimator|NewBlendEstimator|NewRingEstimator]
+func WithEstimator(d Decorator, estimator Estimator) Decorator {
+	estimator = NewThreadSafeEstimator(estimator)
+	eta := func(wc WC, producer func(time.Duration) string, normalizer TimeNormalizer, atRender bool) Decorator {
+		return &estimatedETA{
+			WC:         wc,
+			producer:   producer,
+			estimator:  estimator,
+			normalizer: normalizer,
+			atRender:   atRender,
+		}
+	}
+	speed := func(wc WC, producer func(float64) string, atRender bool) Decorator {
+		return &estimatedSpeed{
+			WC:        wc,
+			producer:  producer,
+			estimator: estimator,
+			atRender:  atRender,
+		}
+	}
+	switch d := d.(type) {
+	case *averageETA:
+		return eta(d.WC, d.producer, d.normalizer, false)
+	case *movingAverageETA:
+		return eta(d.WC, d.producer, d.normalizer, false)
+	case *estimatedETA:
+		return eta(d.WC, d.producer, d.normalizer, d.atRender)
+	case *averageSpeed:
+		return speed(d.WC, d.producer, false)
+	case *movingAverageSpeed:
+		return speed(d.WC, d.producer, false)
+	case *estimatedSpeed:
+		return speed(d.WC, d.producer, d.atRender)
+	}
+	panic(errors.New("decor: WithEstimator requires a built-in ETA or speed decorator"))
+}
+
+type sample struct {
+	current int64
+	t       time.Time
+}
+
+// samples is a chronologically ordered list of samples.
+type samples []sample
+
+func (ss *samples) add(current int64, t time.Time) bool {
+	if n := len(*ss); n != 0 && !t.After((*ss)[n-1].t) {
+		return false
+	}
+	*ss = append(*ss, sample{current, t})
+	return true
+}
+
+// trim drops samples older than cutoff, retaining the latest of them as
+// a baseline.
+func (ss *samples) trim(cutoff time.Time) {
+	var i int
+	for i < len(*ss)-1 && !(*ss)[i+1].t.After(cutoff) {
+		i++
+	}
+	if i != 0 {
+		*ss = append((*ss)[:0], (*ss)[i:]...)
+	}
+}
+
+// NewWindowEstimator returns Estimator of average rate over the last
+// window of time, as opposed to the last N samples. If there is no
+// progress during the window, rate drops down to zero.
+func NewWindowEstimator(window time.Duration) Estimator {
+	return NewThreadSafeEstimator(&windowEstimator{window: window})
+}
+
+type windowEstimator struct {
+	window  time.Duration
+	samples samples
+}
+
+func (e *windowEstimator) Sample(current int64, t time.Time) {
+	if e.samples.add(current, t) {
+		e.samples.trim(t.Add(-e.window))
+	}
+}
+
+func (e *windowEstimator) Rate(now time.Time) (float64, bool) {
+	e.samples.trim(now.Add(-e.window))
+	switch len(e.samples) {
+	case 0:
+		return 0, false
+	case 1:
+		// whole window without progress
+		return 0, now.Sub(e.samples[0].t) >= e.window
+	}
+	first, last := e.samples[0], e.samples[len(e.samples)-1]
+	elapsed := now.Sub(first.t)
+	if elapsed <= 0 {
+		return 0, false
+	}
+	return float64(last.current-first.current) / elapsed.Seconds(), true
+}
+
+func (e *windowEstimator) Reset() {
+	e.samples = e.samples[:0]
+}
+
+// NewRegressionEstimator returns Estimator of rate as slope of linear
+// regression (least squares) over samples of the last window of time.
+func NewRegressionEstimator(window time.Duration) Estimator {
+	return NewThreadSafeEstimator(&regressionEstimator{window: window})
+}
+
+type regressionEstimator struct {
+	window  time.Duration
+	samples samples
+}
+
+func (e *regressionEstimator) Sample(current int64, t time.Time) {
+	if e.samples.add(current, t) {
+		e.samples.trim(t.Add(-e.window))
+	}
+}
+
+func (e *regressionEstimator) Rate(now time.Time) (float64, bool) {
+	e.samples.trim(now.Add(-e.window))
+	n := float64(len(e.samples))
+	if n < 2 {
+		return 0, false
+	}
+	origin := e.samples[0]
+	var sumX, sumY, sumXY, sumXX float64
+	for _, s := range e.samples {
+		x := s.t.Sub(origin.t).Seconds()
+		y := float64(s.current - origin.current)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0, false
+	}
+	return (n*sumXY - sumX*sumY) / denominator, true
+}
+
+func (e *regressionEstimator) Reset() {
+	e.samples = e.samples[:0]
+}
+
+// NewBlendEstimator returns Estimator which blends overall average rate
+// with rate of recent estimator. Weight of recent rate grows linearly
+// from zero at the first sample up to one after rampUp duration, so the
+// average is favoured early and the recent rate later.
+func NewBlendEstimator(recent Estimator, rampUp time.Duration) Estimator {
+	return NewThreadSafeEstimator(&blendEstimator{recent: recent, rampUp: rampUp})
+}
+
+type blendEstimator struct {
+	recent      Estimator
+	rampUp      time.Duration
+	first, last sample
+}
+
+func (e *blendEstimator) Sample(current int64, t time.Time) {
+	if !e.last.t.IsZero() && !t.After(e.last.t) {
+		return
+	}
+	if e.first.t.IsZero() {
+		e.first = sample{current, t}
+	}
+	e.last = sample{current, t}
+	e.recent.Sample(current, t)
+}
+
+func (e *blendEstimator) Rate(now time.Time) (float64, bool) {
+	elapsed := now.Sub(e.first.t)
+	if e.first.t.IsZero() || e.last == e.first || elapsed <= 0 {
+		return 0, false
+	}
+	average := float64(e.last.current-e.first.current) / elapsed.Seconds()
+	recent, ok := e.recent.Rate(now)
+	if !ok {
+		return average, true
+	}
+	weight := 1.0
+	if e.rampUp > 0 {
+		weight = min(float64(elapsed)/float64(e.rampUp), 1)
+	}
+	return (1-weight)*average + weight*recent, true
+}
+
+func (e *blendEstimator) Reset() {
+	e.first, e.last = sample{}, sample{}
+	e.recent.Reset()
+}
+
+// NewRingEstimator returns Estimator of rate between the oldest and the
+// newest of the last size samples, which are kept in a ring buffer. Size
+// less than 2 is treated as 2.
+func NewRingEstimator(size int) Estimator {
+	return NewThreadSafeEstimator(&ringEstimator{buf: make([]sample, max(size, 2))})
+}
+
+type ringEstimator struct {
+	buf   []sample
+	head  int // index of the oldest sample
+	count int
+}
+
+func (e *ringEstimator) Sample(current int64, t time.Time) {
+	if e.count != 0 && !t.After(e.newest().t) {
+		return
+	}
+	if e.count == len(e.buf) {
+		e.buf[e.head] = sample{current, t}
+		e.head = (e.head + 1) % len(e.buf)
+		return
+	}
+	e.buf[(e.head+e.count)%len(e.buf)] = sample{current, t}
+	e.count++
+}
+
+func (e *ringEstimator) newest() sample {
+	return e.buf[(e.head+e.count-1)%len(e.buf)]
+}
+
+func (e *ringEstimator) Rate(time.Time) (float64, bool) {
+	if e.count < 2 {
+		return 0, false
+	}
+	oldest, newest := e.buf[e.head], e.newest()
+	elapsed := newest.t.Sub(oldest.t)
+	return float64(newest.current-oldest.current) / elapsed.Seconds(), true
+}
+
+func (e *ringEstimator) Reset() {
+	e.head, e.count = 0, 0
+}
@@ -0,0 +1,223 @@
+package decor
+
+import (
+	"math"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestWindowEstimator(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e := NewWindowEstimator(10 * time.Second)
+
+	if _, ok := e.Rate(t0); ok {
+		t.Error("Expected no rate without samples")
+	}
+	// 100 per second for 20 seconds, then 10 per second
+	for i := range 21 {
+		e.Sample(int64(i*100), t0.Add(time.Duration(i)*time.Second))
+	}
+	e.Sample(2000, t0.Add(20*time.Second)) // duplicate is ignored
+	for i := 1; i <= 10; i++ {
+		e.Sample(int64(2000+i*10), t0.Add(time.Duration(20+i)*time.Second))
+	}
+
+	rate, ok := e.Rate(t0.Add(30 * time.Second))
+	if !ok || rate != 10 {
+		t.Errorf("Expected rate 10 over the window, got: %v, %v", rate, ok)
+	}
+	// no progress during the next 10 seconds
+	rate, ok = e.Rate(t0.Add(40 * time.Second))
+	if !ok || rate != 0 {
+		t.Errorf("Expected rate 0 after stall, got: %v, %v", rate, ok)
+	}
+
+	e.Reset()
+	if _, ok := e.Rate(t0.Add(40 * time.Second)); ok {
+		t.Error("Expected no rate after reset")
+	}
+}
+
+func TestRegressionEstimator(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e := NewRegressionEstimator(time.Minute)
+
+	// noisy progress around 50 per second
+	noise := []int64{5, -5, 3, -3, 0}
+	for i := range 20 {
+		e.Sample(int64(i*50)+noise[i%len(noise)], t0.Add(time.Duration(i)*time.Second))
+	}
+	rate, ok := e.Rate(t0.Add(20 * time.Second))
+	if !ok || math.Abs(rate-50) > 1 {
+		t.Errorf("Expected rate about 50, got: %v, %v", rate, ok)
+	}
+}
+
+func TestBlendEstimator(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e := NewBlendEstimator(NewWindowEstimator(5*time.Second), 20*time.Second)
+
+	// 10 per second for 10 seconds, then 100 per second
+	for i := range 11 {
+		e.Sample(int64(i*10), t0.Add(time.Duration(i)*time.Second))
+	}
+	for i := 1; i <= 10; i++ {
+		e.Sample(int64(100+i*100), t0.Add(time.Duration(10+i)*time.Second))
+	}
+
+	// at 20s recent weight is 1, i.e. recent rate only
+	rate, ok := e.Rate(t0.Add(20 * time.Second))
+	if !ok || rate != 100 {
+		t.Errorf("Expected recent rate 100, got: %v, %v", rate, ok)
+	}
+
+	e.Reset()
+	e.Sample(0, t0)
+	e.Sample(20, t0.Add(2*time.Second))
+	// recent weight is 0.1, both rates are 10
+	rate, ok = e.Rate(t0.Add(2 * time.Second))
+	if !ok || math.Abs(rate-10) > 1e-9 {
+		t.Errorf("Expected rate 10, got: %v, %v", rate, ok)
+	}
+}
+
+func TestEstimatedETA(t *testing.T) {
+	now := time.Now()
+	e := NewWindowEstimator(time.Hour)
+	speed := EstimatedSpeed(0, "%.0f", e)
+	eta := EstimatedETA(ET_STYLE_GO, e, nil)
+
+	if got, _ := eta.Decor(Statistics{Total: 100}); got != "0s" {
+		t.Errorf("Expected 0s without estimate, got: %q", got)
+	}
+	eta.Decor(Statistics{Total: 100, Current: 10, Updated: now.Add(-20 * time.Second)})
+	stat := Statistics{Total: 100, Current: 20, Updated: now.Add(-10 * time.Second)}
+	// rate is about 0.5 per second, decaying while time passes
+	if got, _ := speed.Decor(stat); got != "1" && got != "0" {
+		t.Errorf("Expected speed about 0.5, got: %q", got)
+	}
+	remaining, ok := eta.(etaEstimator).estimate(stat)
+	if !ok || remaining < 150*time.Second || remaining > 170*time.Second {
+		t.Errorf("Expected about 160s remaining, got: %v, %v", remaining, ok)
+	}
+}
+
+func TestRingEstimator(t *testing.T) {
+	t0 := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	e := NewRingEstimator(3)
+
+	e.Sample(0, t0)
+	if _, ok := e.Rate(t0); ok {
+		t.Error("Expected no rate with single sample")
+	}
+	e.Sample(100, t0.Add(time.Second))
+	e.Sample(200, t0.Add(2*time.Second))
+	if rate, ok := e.Rate(t0); !ok || rate != 100 {
+		t.Errorf("Expected rate 100, got: %v, %v", rate, ok)
+	}
+	// oldest samples are overwritten
+	e.Sample(210, t0.Add(3*time.Second))
+	e.Sample(220, t0.Add(4*time.Second))
+	if rate, ok := e.Rate(t0); !ok || rate != 10 {
+		t.Errorf("Expected rate 10, got: %v, %v", rate, ok)
+	}
+	// stall at render time
+	e.Sample(220, t0.Add(5*time.Second))
+	e.Sample(220, t0.Add(6*time.Second))
+	if rate, ok := e.Rate(t0); !ok || rate != 0 {
+		t.Errorf("Expected rate 0, got: %v, %v", rate, ok)
+	}
+}
+
+func TestWindowedSpeed(t *testing.T) {
+	d := WindowedSpeed(0, "%.0f", 10)
+	if got, _ := d.Decor(Statistics{Total: 100}); got != "0" {
+		t.Errorf("Expected 0 with single sample, got: %q", got)
+	}
+	time.Sleep(100 * time.Millisecond)
+	// no EwmaUpdate and no Updated timestamp, only render time samples
+	if got, _ := d.Decor(Statistics{Total: 100, Current: 50}); got == "0" {
+		t.Errorf("Expected positive speed, got: %q", got)
+	}
+}
+
+type recordingEstimator struct {
+	sampled, rated time.Time
+}
+
+func (e *recordingEstimator) Sample(_ int64, t time.Time) { e.sampled = t }
+func (e *recordingEstimator) Reset()                      {}
+
+func (e *recordingEstimator) Rate(now time.Time) (float64, bool) {
+	e.rated = now
+	return 0, false
+}
+
+func TestEstimatedSpeedPaused(t *testing.T) {
+	estimator := new(recordingEstimator)
+	d := EstimatedSpeed(0, "", estimator)
+	updated := time.Now().Add(-time.Minute)
+	stat := Statistics{Updated: updated, PausedFor: 20 * time.Second}
+	start := time.Now()
+	d.Decor(stat)
+
+	// time spent paused is left out, so estimator sees no gap
+	if expected := updated.Add(-20 * time.Second); !estimator.sampled.Equal(expected) {
+		t.Errorf("Expected sample at %v, got: %v", expected, estimator.sampled)
+	}
+	if elapsed := start.Add(-20 * time.Second).Sub(estimator.rated); elapsed > 0 || elapsed < -time.Second {
+		t.Errorf("Expected rate about 20s ago, got: %v", estimator.rated)
+	}
+}
+
+func TestWithEstimator(t *testing.T) {
+	d := WithEstimator(AverageSpeed(0, "%.0f", WC{W: 4}), NewRingEstimator(2))
+	t0 := time.Now().Add(-time.Minute)
+	d.Decor(Statistics{Current: 0, Updated: t0})
+	got, _ := d.Decor(Statistics{Current: 50, Updated: t0.Add(time.Second)})
+	if expected := "  50"; got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+
+	defer func() {
+		if _, ok := recover().(error); !ok {
+			t.Error("Expected panic with error")
+		}
+	}()
+	WithEstimator(Name("eta"), NewRingEstimator(2))
+}
+
+func TestEstimatorSharedConcurrently(t *testing.T) {
+	estimator := NewWindowEstimator(time.Second)
+	speed := EstimatedSpeed(0, "", estimator)
+	eta := EstimatedETA(ET_STYLE_GO, estimator, nil)
+	var wg sync.WaitGroup
+	for _, d := range []Decorator{speed, eta} {
+		wg.Go(func() {
+			for i := range 100 {
+				d.Decor(Statistics{Total: 1000, Current: int64(i), Updated: time.Now()})
+			}
+		})
+	}
+	wg.Wait()
+}
+
+func TestWithEstimatorWindowed(t *testing.T) {
+	updated := time.Now().Add(-time.Minute)
+	stat := Statistics{Total: 100, Current: 10, Updated: updated}
+	for name, d := range map[string]func(Estimator) Decorator{
+		"speed": func(e Estimator) Decorator { return WithEstimator(WindowedSpeed(0, "", 4), e) },
+		"eta":   func(e Estimator) Decorator { return WithEstimator(WindowedETA(ET_STYLE_GO, 4, nil), e) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			estimator := new(recordingEstimator)
+			start := time.Now()
+			d(estimator).Decor(stat)
+			// sampled at render, not at the last update
+			if estimator.sampled.Before(start) {
+				t.Errorf("Expected sample at render time, got: %v", estimator.sampled)
+			}
+		})
+	}
+}
@@ -1,8 +1,9 @@
 package decor
 
 import (
-	"fmt"
+	"cmp"
 	"math"
+	"sync/atomic"
 	"time"
 
 	"github.com/VividCortex/ewma"
@@ -11,10 +12,25 @@ import (
 var (
 	_ Decorator        = (*movingAverageETA)(nil)
 	_ EwmaDecorator    = (*movingAverageETA)(nil)
+	_ ResetListener    = (*movingAverageETA)(nil)
 	_ Decorator        = (*averageETA)(nil)
 	_ AverageDecorator = (*averageETA)(nil)
+	_ ResetListener    = (*averageETA)(nil)
+	_ etaEstimator     = (*movingAverageETA)(nil)
+	_ etaEstimator     = (*averageETA)(nil)
+	_ Decorator        = (*estimatedETA)(nil)
+	_ ResetListener    = (*estimatedETA)(nil)
+	_ etaEstimator     = (*estimatedETA)(nil)
 )
 
+// etaEstimator is implemented by built-in ETA decorators, so their
+// estimate can be shared with FinishTime decorator.
+type etaEstimator interface {
+	// estimate doesn't sample, so it's safe to call by any number of
+	// wrappers
+	estimate(Statistics) (remaining time.Duration, ok bool)
+}
+
 // TimeNormalizer interface. Implementers could be passed into
 // MovingAverageETA, in order to affect i.e. normalize its output.
 type TimeNormalizer interface {
@@ -32,25 +48,25 @@ func (f TimeNormalizerFunc) Normalize(src time.Duration) time.Duration {
 // EwmaETA exponential-weighted-moving-average based ETA decorator. For this
 // decorator to work correctly you have to measure each iteration's duration
 // and pass it to one of the (*Bar).EwmaIncr... family methods.
-func EwmaETA(style TimeStyle, age float64, wcc ...WC) Decorator {
+func EwmaETA(style TimeFormatter, age float64, wcc ...WC) Decorator {
 	return EwmaNormalizedETA(style, age, nil, wcc...)
 }
 
 // EwmaNormalizedETA same as EwmaETA but with TimeNormalizer option.
-func EwmaNormalizedETA(style TimeStyle, age float64, normalizer TimeNormalizer, wcc ...WC) Decorator {
-	return MovingAverageETA(style, ewma.NewMovingAverage(age), normalizer, wcc...)
+func EwmaNormalizedETA(style TimeFormatter, age float64, normalizer TimeNormalizer, wcc ...WC) Decorator {
+	return MovingAverageETA(style, newEwma(age), normalizer, wcc...)
 }
 
 // MovingAverageETA decorator relies on MovingAverage implementation to calculate its average.
 //
-//	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
 //
 //	`average` implementation of MovingAverage interface
 //
 //	`normalizer` available implementations are [FixedIntervalTimeNormalizer|MaxTolerateTimeNormalizer]
 //
 //	`wcc` optional WC config
-func MovingAverageETA(style TimeStyle, average ewma.MovingAverage, normalizer TimeNormalizer, wcc ...WC) Decorator {
+func MovingAverageETA(style TimeFormatter, average ewma.MovingAverage, normalizer TimeNormalizer, wcc ...WC) Decorator {
 	d := &movingAverageETA{
 		WC:         initWC(wcc...),
 		producer:   chooseTimeProducer(style),
@@ -66,18 +82,29 @@ type movingAverageETA struct {
 	average    ewma.MovingAverage
 	normalizer TimeNormalizer
 	zDur       time.Duration
+	zReset     atomic.Bool // zDur is cleared by the next EwmaUpdate
 }
 
 func (d *movingAverageETA) Decor(s Statistics) (string, int) {
-	v := math.Round(d.average.Value())
-	remaining := time.Duration((s.Total - s.Current) * int64(v))
+	if msg, ok := s.stalled(); ok {
+		return d.Format(msg)
+	}
+	remaining, _ := d.estimate(s)
 	if d.normalizer != nil {
 		remaining = d.normalizer.Normalize(remaining)
 	}
 	return d.Format(d.producer(remaining))
 }
 
+func (d *movingAverageETA) estimate(s Statistics) (time.Duration, bool) {
+	v := math.Round(d.average.Value())
+	return time.Duration((s.Total - s.Current) * int64(v)), v != 0
+}
+
 func (d *movingAverageETA) EwmaUpdate(n int64, dur time.Duration) {
+	if d.zReset.Swap(false) {
+		d.zDur = 0
+	}
 	durPerItem := float64(d.zDur+dur) / float64(n)
 	if math.IsInf(durPerItem, 0) || math.IsNaN(durPerItem) {
 		d.zDur += dur
@@ -87,25 +114,32 @@ func (d *movingAverageETA) EwmaUpdate(n int64, dur time.Duration) {
 	}
 }
 
-// AverageETA decorator. It's wrapper of NewAverageETA.
+func (d *movingAverageETA) OnReset() {
+	resetMovingAverage(d.average)
+	// zDur is owned by EwmaUpdate caller, so it's cleared lazily
+	d.zReset.Store(true)
+}
+
+// AverageETA decorator. It's wrapper of NewAverageETA with zero start
+// time, i.e. ETA is estimated since the first update of bar's current.
 //
-//	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
 //
 //	`wcc` optional WC config
-func AverageETA(style TimeStyle, wcc ...WC) Decorator {
-	return NewAverageETA(style, time.Now(), nil, wcc...)
+func AverageETA(style TimeFormatter, wcc ...WC) Decorator {
+	return NewAverageETA(style, time.Time{}, nil, wcc...)
 }
 
 // NewAverageETA decorator with user provided start time.
 //
-//	`style` one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_HHMM|ET_STYLE_MMSS]
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
 //
-//	`start` start time
+//	`start` start time, if zero decor.Statistics.Started is used
 //
 //	`normalizer` available implementations are [FixedIntervalTimeNormalizer|MaxTolerateTimeNormalizer]
 //
 //	`wcc` optional WC config
-func NewAverageETA(style TimeStyle, start time.Time, normalizer TimeNormalizer, wcc ...WC) Decorator {
+func NewAverageETA(style TimeFormatter, start time.Time, normalizer TimeNormalizer, wcc ...WC) Decorator {
 	d := &averageETA{
 		WC:         initWC(wcc...),
 		start:      start,
@@ -123,22 +157,115 @@ type averageETA struct {
 }
 
 func (d *averageETA) Decor(s Statistics) (string, int) {
-	var remaining time.Duration
-	if s.Current != 0 {
-		durPerItem := float64(time.Since(d.start)) / float64(s.Current)
-		durPerItem = math.Round(durPerItem)
-		remaining = time.Duration((s.Total - s.Current) * int64(durPerItem))
-		if d.normalizer != nil {
-			remaining = d.normalizer.Normalize(remaining)
-		}
+	if msg, ok := s.stalled(); ok {
+		return d.Format(msg)
+	}
+	remaining, ok := d.estimate(s)
+	if ok && d.normalizer != nil {
+		remaining = d.normalizer.Normalize(remaining)
 	}
 	return d.Format(d.producer(remaining))
 }
 
+func (d *averageETA) estimate(s Statistics) (time.Duration, bool) {
+	start := cmp.Or(d.start, s.Started)
+	if s.Current == 0 || start.IsZero() {
+		return 0, false
+	}
+	durPerItem := float64(s.activeSince(start)) / float64(s.Current)
+	durPerItem = math.Round(durPerItem)
+	return time.Duration((s.Total - s.Current) * int64(durPerItem)), true
+}
+
 func (d *averageETA) AverageAdjust(start time.Time) {
 	d.start = start
 }
 
+func (d *averageETA) OnReset() {
+	// zero start is restarted by the bar itself
+	if !d.start.IsZero() {
+		d.start = time.Now()
+	}
+}
+
+// EstimatedETA decorator relies on Estimator implementation to calculate
+// its rate. Estimator is sampled with bar's current and time of its last
+// update, so it could be shared with EstimatedSpeed decorator of the same
+// bar.
+//
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
+//
+//	`estimator` e.g. one of [NewWindowEstimator|NewRegressionEstimator|NewBlendEstimator|NewRingEstimator]
+//
+//	`normalizer` available implementations are [FixedIntervalTimeNormalizer|MaxTolerateTimeNormalizer]
+//
+//	`wcc` optional WC config
+func EstimatedETA(style TimeFormatter, estimator Estimator, normalizer TimeNormalizer, wcc ...WC) Decorator {
+	d := &estimatedETA{
+		WC:         initWC(wcc...),
+		producer:   chooseTimeProducer(style),
+		estimator:  NewThreadSafeEstimator(estimator),
+		normalizer: normalizer,
+	}
+	return d
+}
+
+type estimatedETA struct {
+	WC
+	producer   func(time.Duration) string
+	estimator  Estimator
+	normalizer TimeNormalizer
+	atRender   bool
+}
+
+// WindowedETA decorator samples bar's current on each render, keeping the
+// last `size` samples in a ring buffer. Its rate is measured between the
+// oldest and the newest sample, so it reflects recent progress with no
+// need to call (*Bar).EwmaIncr... family methods.
+//
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
+//
+//	`size` number of samples, i.e. window is about size times refresh rate
+//
+//	`normalizer` available implementations are [FixedIntervalTimeNormalizer|MaxTolerateTimeNormalizer]
+//
+//	`wcc` optional WC config
+func WindowedETA(style TimeFormatter, size int, normalizer TimeNormalizer, wcc ...WC) Decorator {
+	d := &estimatedETA{
+		WC:         initWC(wcc...),
+		producer:   chooseTimeProducer(style),
+		estimator:  NewThreadSafeEstimator(NewRingEstimator(size)),
+		normalizer: normalizer,
+		atRender:   true,
+	}
+	return d
+}
+
+func (d *estimatedETA) Decor(s Statistics) (string, int) {
+	sampleEstimator(d.estimator, s, d.atRender)
+	remaining, ok := d.estimate(s)
+	if msg, ok := s.stalled(); ok {
+		return d.Format(msg)
+	}
+	if ok && d.normalizer != nil {
+		remaining = d.normalizer.Normalize(remaining)
+	}
+	return d.Format(d.producer(remaining))
+}
+
+func (d *estimatedETA) estimate(s Statistics) (time.Duration, bool) {
+	rate, ok := d.estimator.Rate(s.activeTime(time.Now()))
+	if !ok || rate <= 0 {
+		return 0, false
+	}
+	remaining := float64(s.Total-s.Current) / rate
+	return time.Duration(math.Round(remaining * 1e9)), true
+}
+
+func (d *estimatedETA) OnReset() {
+	d.estimator.Reset()
+}
+
 // MaxTolerateTimeNormalizer returns implementation of TimeNormalizer.
 func MaxTolerateTimeNormalizer(maxTolerate time.Duration) TimeNormalizer {
 	var normalized time.Duration
@@ -179,35 +306,3 @@ func FixedIntervalTimeNormalizer(updInterval int) TimeNormalizer {
 		return remaining
 	})
 }
-
-func chooseTimeProducer(style TimeStyle) func(time.Duration) string {
-	switch style {
-	case ET_STYLE_HHMMSS:
-		return func(remaining time.Duration) string {
-			hours := int64(remaining/time.Hour) % 60
-			minutes := int64(remaining/time.Minute) % 60
-			seconds := int64(remaining/time.Second) % 60
-			return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
-		}
-	case ET_STYLE_HHMM:
-		return func(remaining time.Duration) string {
-			hours := int64(remaining/time.Hour) % 60
-			minutes := int64(remaining/time.Minute) % 60
-			return fmt.Sprintf("%02d:%02d", hours, minutes)
-		}
-	case ET_STYLE_MMSS:
-		return func(remaining time.Duration) string {
-			hours := int64(remaining/time.Hour) % 60
-			minutes := int64(remaining/time.Minute) % 60
-			seconds := int64(remaining/time.Second) % 60
-			if hours > 0 {
-				return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
-			}
-			return fmt.Sprintf("%02d:%02d", minutes, seconds)
-		}
-	default:
-		return func(remaining time.Duration) string {
-			return remaining.Truncate(time.Second).String()
-		}
-	}
-}
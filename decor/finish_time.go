@@ -0,0 +1,64 @@
+package decor
+
+import (
+	"cmp"
+	"errors"
+	"time"
+)
+
+// FinishTime decorator displays wall-clock time the bar is estimated to
+// complete at. Estimate is read from provided ETA decorator, so both
+// agree. The ETA decorator has to be added to the same bar, as it's the
+// one which does sampling. Once bar is completed actual finish
+// time is displayed. If finish time isn't today, it's prefixed with
+// "tomorrow" or with the date.
+//
+//	`eta` one of [AverageETA|NewAverageETA|EwmaETA|MovingAverageETA|EstimatedETA|WindowedETA] decorators
+//
+//	`layout` time layout, "15:04" if empty
+//
+//	`loc` time zone, time.Local if nil
+//
+//	`wcc` optional WC config
+func FinishTime(eta Decorator, layout string, loc *time.Location, wcc ...WC) Decorator {
+	estimator, ok := unwrapAs[etaEstimator](eta)
+	if !ok {
+		panic(errors.New("decor: FinishTime requires a built-in ETA decorator"))
+	}
+	if layout == "" {
+		layout = "15:04"
+	}
+	if loc == nil {
+		loc = time.Local
+	}
+	fn := func(s Statistics) string {
+		var finish time.Time
+		switch {
+		case s.Completed:
+			finish = cmp.Or(s.Finished, time.Now())
+		case s.Aborted:
+			return ""
+		default:
+			remaining, ok := estimator.estimate(s)
+			if !ok {
+				return ""
+			}
+			finish = time.Now().Add(remaining)
+		}
+		return formatFinishTime(finish.In(loc), time.Now().In(loc), layout)
+	}
+	return Any(fn, wcc...)
+}
+
+func formatFinishTime(finish, now time.Time, layout string) string {
+	fy, fm, fd := finish.Date()
+	ny, nm, nd := now.Date()
+	if fy == ny && fm == nm && fd == nd {
+		return finish.Format(layout)
+	}
+	ty, tm, td := now.AddDate(0, 0, 1).Date()
+	if fy == ty && fm == tm && fd == td {
+		return Translate("tomorrow") + " " + finish.Format(layout)
+	}
+	return finish.Format("Jan 2 " + layout)
+}
@@ -0,0 +1,89 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFinishTime(t *testing.T) {
+	now := time.Now()
+	started := now.Add(-10 * time.Minute)
+	d := FinishTime(AverageETA(ET_STYLE_GO), "15:04", time.UTC)
+
+	if got, _ := d.Decor(Statistics{Total: 100}); got != "" {
+		t.Errorf("Expected empty output without estimate, got: %q", got)
+	}
+
+	// half done in 10 minutes, so 10 more minutes to go
+	got, _ := d.Decor(Statistics{Total: 100, Current: 50, Started: started})
+	// minute might change while decorating
+	for _, at := range []time.Time{now, time.Now()} {
+		expected := formatFinishTime(at.Add(10*time.Minute).In(time.UTC), at.In(time.UTC), "15:04")
+		if got == expected {
+			break
+		}
+		if at != now {
+			t.Errorf("Expected: %q, got: %q", expected, got)
+		}
+	}
+
+	finished := time.Date(2024, 5, 1, 14, 32, 0, 0, time.UTC)
+	got, _ = d.Decor(Statistics{Total: 100, Current: 100, Completed: true, Finished: finished})
+	if len(got) < 5 || got[len(got)-5:] != "14:32" {
+		t.Errorf("Expected actual finish time 14:32, got: %q", got)
+	}
+}
+
+func TestFormatFinishTime(t *testing.T) {
+	now := time.Date(2024, 5, 1, 23, 0, 0, 0, time.UTC)
+	cases := map[string]struct {
+		finish   time.Time
+		expected string
+	}{
+		"today":    {now.Add(30 * time.Minute), "23:30"},
+		"tomorrow": {now.Add(3*time.Hour + 10*time.Minute), "tomorrow 02:10"},
+		"later":    {now.Add(49 * time.Hour), "May 4 00:00"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := formatFinishTime(tc.finish, now, "15:04"); got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestFinishTimePanicsOnNonETA(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(error); !ok {
+			t.Error("Expected panic with error")
+		}
+	}()
+	FinishTime(Name("eta"), "", nil)
+}
+
+type countingEstimator struct {
+	Estimator
+	samples int
+}
+
+func (e *countingEstimator) Sample(current int64, t time.Time) {
+	e.samples++
+	e.Estimator.Sample(current, t)
+}
+
+func TestFinishTimeDoesNotSample(t *testing.T) {
+	estimator := &countingEstimator{Estimator: NewRingEstimator(4)}
+	eta := EstimatedETA(ET_STYLE_GO, estimator, nil)
+	d := FinishTime(eta, "", time.UTC)
+
+	updated := time.Now().Add(-time.Minute)
+	for i := range 3 {
+		stat := Statistics{Total: 100, Current: int64(i * 10), Updated: updated.Add(time.Duration(i) * time.Second)}
+		eta.Decor(stat)
+		d.Decor(stat)
+	}
+	if estimator.samples != 3 {
+		t.Errorf("Expected 3 samples, got: %d", estimator.samples)
+	}
+}
@@ -0,0 +1,127 @@
+package decor
+
+import (
+	"bytes"
+	"cmp"
+	"fmt"
+	"strings"
+	"sync/atomic"
+	"unicode/utf8"
+)
+
+var locale atomic.Pointer[localeState]
+
+// Locale controls separators of numbers formatted by SizeB1024,
+// SizeB1000, percentage and other Unit based values, by counters, speed
+// with no unit and other built-in numeric decorators, and translation of
+// messages produced by built-in decorators, like unit suffixes, "/s" of
+// speed decorators, "d", "h", "m", "s" of ET_STYLE_GO and ET_STYLE_COMPACT
+// time styles, phrases of ET_STYLE_HUMAN like "about %d minutes",
+// "tomorrow" of FinishTime and default format of Retries decorator.
+// Messages are keyed by default English messages, see Translate.
+type Locale struct {
+	Decimal  string            // decimal separator, "." if empty
+	Group    string            // digit grouping separator, no grouping if empty
+	Messages map[string]string // translations keyed by default messages
+}
+
+type localeState struct {
+	Locale
+	timeReplacer *strings.Replacer
+}
+
+// SetLocale sets global locale, which is effective for decorators and
+// formatters in use by any container. As formatters like SizeB1024 have
+// no bar context, locale isn't configurable per container. Messages map
+// must not be modified after the call. Pass nil to restore defaults.
+func SetLocale(l *Locale) {
+	if l == nil {
+		locale.Store(nil)
+		return
+	}
+	state := &localeState{Locale: *l}
+	state.timeReplacer = strings.NewReplacer(
+		"h", state.translate("h"),
+		"m", state.translate("m"),
+		"s", state.translate("s"),
+	)
+	locale.Store(state)
+}
+
+// Translate returns translation of msg by global locale's catalogue, or
+// msg itself if there is no translation. Could be used with user provided
+// messages as well, for example OnComplete(d, Translate("done")).
+func Translate(msg string) string {
+	return locale.Load().translate(msg)
+}
+
+func (l *localeState) translate(msg string) string {
+	if l == nil {
+		return msg
+	}
+	if t, ok := l.Messages[msg]; ok {
+		return t
+	}
+	return msg
+}
+
+// localizeNumber applies separators of global locale to b, which is
+// expected to be output of strconv.AppendFloat with 'f' format.
+func localizeNumber(b []byte) []byte {
+	l := locale.Load()
+	if l == nil || (l.Decimal == "" && l.Group == "") {
+		return b
+	}
+	intPart, fracPart, hasFrac := bytes.Cut(b, []byte{'.'})
+	res := make([]byte, 0, len(b)+len(intPart)/3*len(l.Group)+len(l.Decimal))
+	if len(intPart) != 0 && intPart[0] == '-' {
+		res = append(res, '-')
+		intPart = intPart[1:]
+	}
+	for i, c := range intPart {
+		if i != 0 && l.Group != "" && (len(intPart)-i)%3 == 0 {
+			res = append(res, l.Group...)
+		}
+		res = append(res, c)
+	}
+	if hasFrac {
+		res = append(res, cmp.Or(l.Decimal, ".")...)
+		res = append(res, fracPart...)
+	}
+	return res
+}
+
+// localizesNumbers reports whether global locale has any separators set.
+func localizesNumbers() bool {
+	l := locale.Load()
+	return l != nil && (l.Decimal != "" || l.Group != "")
+}
+
+// writeNumber writes b, which is expected to be output of strconv.AppendInt
+// or strconv.AppendFloat with 'f' format, applying separators of global
+// locale and padding to width of f.
+func writeNumber(f fmt.State, b []byte) {
+	b = localizeNumber(b)
+	if width, ok := f.Width(); ok {
+		if pad := width - utf8.RuneCount(b); pad > 0 {
+			fill := bytes.Repeat([]byte{' '}, pad)
+			if f.Flag('-') {
+				b = append(b, fill...)
+			} else {
+				b = append(fill, b...)
+			}
+		}
+	}
+	_, err := f.Write(b)
+	if err != nil {
+		panic(err)
+	}
+}
+
+// localizeDuration translates unit markers of time.Duration string.
+func localizeDuration(str string) string {
+	if l := locale.Load(); l != nil {
+		return l.timeReplacer.Replace(str)
+	}
+	return str
+}
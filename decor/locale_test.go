@@ -0,0 +1,97 @@
+package decor
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestLocale(t *testing.T) {
+	SetLocale(&Locale{
+		Decimal: ",",
+		Group:   ".",
+		Messages: map[string]string{
+			"MB": "Mo",
+			"/s": " par s",
+			"m":  "min",
+		},
+	})
+	t.Cleanup(func() { SetLocale(nil) })
+
+	cases := map[string]struct {
+		value    fmt.Formatter
+		verb     string
+		expected string
+	}{
+		"SizeB1000 % .1f":  {SizeB1000(1500000), "% .1f", "1,5 Mo"},
+		"SizeB1024 %d":     {SizeB1024(2048), "%d", "2KiB"},
+		"percentage %.1f":  {percentageType(12.5), "%.1f", "12,5%"},
+		"percentage % d":   {percentageType(100), "% d", "100 %"},
+		"negative %.1f":    {unitValue{UnitSI(""), -1234.5}, "%.1f", "-1,2k"},
+		"grouping %.0f":    {unitValue{UnitItems("rows"), 1234567}, "% .0f", "1.234.567 rows"},
+		"e verb untouched": {SizeB1000(1500), "%.1e", "1.5e+00KB"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := fmt.Sprintf(tc.verb, tc.value)
+			if got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+
+	if got := Translate("done"); got != "done" {
+		t.Errorf("Expected untranslated %q, got: %q", "done", got)
+	}
+	if got := fmt.Sprintf("%d", FmtAsSpeed(SizeB1000(5e6))); got != "5Mo par s" {
+		t.Errorf("Expected: %q, got: %q", "5Mo par s", got)
+	}
+	if got := chooseTimeProducer(ET_STYLE_GO)(90 * time.Second); got != "1min30s" {
+		t.Errorf("Expected: %q, got: %q", "1min30s", got)
+	}
+}
+
+func TestLocaleSetAfterConstruction(t *testing.T) {
+	retries := Retries("")
+	counts := ContainerCounts("")
+	SetLocale(&Locale{Messages: map[string]string{
+		"retry %d": "essai %d",
+		"active: %d, queued: %d, completed: %d, aborted: %d": "actifs: %d, en attente: %d, finis: %d, annulés: %d",
+	}})
+	t.Cleanup(func() { SetLocale(nil) })
+
+	if got, _ := retries.Decor(Statistics{Retries: 2}); got != "essai 2" {
+		t.Errorf("Expected: %q, got: %q", "essai 2", got)
+	}
+	expected := "actifs: 1, en attente: 2, finis: 3, annulés: 4"
+	stat := Statistics{Container: &ContainerStatistics{Active: 1, Queued: 2, Completed: 3, Aborted: 4}}
+	if got, _ := counts.Decor(stat); got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestLocaleNumericDecorators(t *testing.T) {
+	SetLocale(&Locale{Decimal: ",", Group: "."})
+	t.Cleanup(func() { SetLocale(nil) })
+
+	cases := map[string]struct {
+		d        Decorator
+		stat     Statistics
+		expected string
+	}{
+		"counters %d":    {CountersNoUnit("%d / %d"), Statistics{Current: 1500, Total: 20000}, "1.500 / 20.000"},
+		"counters width": {CountersNoUnit("%6d|%-6d|"), Statistics{Current: 1500, Total: 2000}, " 1.500|2.000 |"},
+		"scaled %.1f":    {CurrentNoUnit("%.1f"), Statistics{Current: 12345, Scale: 10}, "1.234,5"},
+		"scaled %d":      {TotalNoUnit("%d"), Statistics{Total: 12345, Scale: 10}, "1.235"},
+		"hex untouched":  {CurrentNoUnit("%x"), Statistics{Current: 4096}, "1000"},
+		"speed no unit":  {AverageSpeed(0, "%.2f"), Statistics{}, "0,00"},
+		"retries":        {Retries("%d"), Statistics{Retries: 1200}, "1.200"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got, _ := tc.d.Decor(tc.stat); got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
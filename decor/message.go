@@ -0,0 +1,38 @@
+package decor
+
+import "github.com/mattn/go-runewidth"
+
+// Message decorator displays bar's status message, set by
+// (*mpb.Bar).SetMessage. Message is truncated with trailing "…" to fit
+// available width. If WC.W is set, it's treated as max width as well.
+//
+//	`wcc` optional WC config
+func Message(wcc ...WC) Decorator {
+	return truncated(func(s Statistics) string { return s.Message }, wcc...)
+}
+
+// Label decorator displays bar's label, set by (*mpb.Bar).SetLabel.
+// Label is truncated the same way Message is.
+//
+//	`wcc` optional WC config
+func Label(wcc ...WC) Decorator {
+	return truncated(func(s Statistics) string { return s.Label }, wcc...)
+}
+
+func truncated(text func(Statistics) string, wcc ...WC) Decorator {
+	wc := initWC(wcc...)
+	fn := func(s Statistics) string {
+		width := s.AvailableWidth
+		if wc.W > 0 {
+			width = min(width, wc.W)
+		}
+		if (wc.C & DextraSpace) != 0 {
+			width--
+		}
+		if width <= 0 {
+			return ""
+		}
+		return runewidth.Truncate(text(s), width, "…")
+	}
+	return Any(fn, wc)
+}
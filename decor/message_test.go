@@ -0,0 +1,35 @@
+package decor
+
+import "testing"
+
+func TestMessageTruncate(t *testing.T) {
+	cases := map[string]struct {
+		wc       WC
+		width    int
+		message  string
+		expected string
+	}{
+		"fits":        {WC{}, 20, "processing", "processing"},
+		"available":   {WC{}, 6, "processing", "proce…"},
+		"max width":   {WC{W: 6}, 20, "processing", "proce…"},
+		"extra space": {WC{C: DextraSpace}, 6, "processing", " proc…"},
+		"no width":    {WC{}, 0, "processing", ""},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			d := Message(tc.wc)
+			got, _ := d.Decor(Statistics{AvailableWidth: tc.width, Message: tc.message})
+			if got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestLabel(t *testing.T) {
+	d := Label()
+	got, _ := d.Decor(Statistics{AvailableWidth: 80, Label: "label"})
+	if got != "label" {
+		t.Errorf("Expected: %q, got: %q", "label", got)
+	}
+}
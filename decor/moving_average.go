@@ -15,7 +15,8 @@ var (
 
 type threadSafeMovingAverage struct {
 	ewma.MovingAverage
-	mut sync.RWMutex
+	mut   sync.RWMutex
+	reset func() ewma.MovingAverage
 }
 
 func (s *threadSafeMovingAverage) Set(value float64) {
@@ -36,6 +37,35 @@ func (s *threadSafeMovingAverage) Value() float64 {
 	return s.MovingAverage.Value()
 }
 
+// Reset restarts the average. Unless the average is constructed by
+// newEwma, it's done by setting its value to zero.
+func (s *threadSafeMovingAverage) Reset() {
+	s.mut.Lock()
+	defer s.mut.Unlock()
+	if s.reset != nil {
+		s.MovingAverage = s.reset()
+	} else {
+		s.MovingAverage.Set(0)
+	}
+}
+
+func newEwma(age float64) ewma.MovingAverage {
+	return &threadSafeMovingAverage{
+		MovingAverage: ewma.NewMovingAverage(age),
+		reset: func() ewma.MovingAverage {
+			return ewma.NewMovingAverage(age)
+		},
+	}
+}
+
+func resetMovingAverage(average ewma.MovingAverage) {
+	if r, ok := average.(interface{ Reset() }); ok {
+		r.Reset()
+	} else {
+		average.Set(0)
+	}
+}
+
 // NewThreadSafeMovingAverage converts provided ewma.MovingAverage
 // into thread safe ewma.MovingAverage.
 func NewThreadSafeMovingAverage(average ewma.MovingAverage) ewma.MovingAverage {
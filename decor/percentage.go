@@ -26,6 +26,9 @@ func (s percentageType) Format(st fmt.State, verb rune) {
 	}
 
 	b := strconv.AppendFloat(make([]byte, 0, 16), float64(s), byte(verb), prec, 64)
+	if verb == 'f' {
+		b = localizeNumber(b)
+	}
 	if st.Flag(' ') {
 		b = append(b, ' ', '%')
 	} else {
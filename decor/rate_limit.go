@@ -0,0 +1,26 @@
+package decor
+
+// RateLimit decorator displays throughput limit, set by either
+// mpb.BarRateLimit or mpb.WithRateLimit option. Displays nothing if
+// there is no limit. Bar's own limit takes precedence over container's.
+//
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`wcc` optional WC config
+//
+// format examples:
+//
+//	unit=SizeB1024(0), format="%.1f"  output: "1.0MiB/s"
+//	unit=SizeB1000(0), format="% .1f" output: "1.0 MB/s"
+func RateLimit(unit any, format string, wcc ...WC) Decorator {
+	producer := chooseSpeedProducer(unit, format)
+	fn := func(s Statistics) string {
+		if s.RateLimit <= 0 {
+			return ""
+		}
+		return producer(float64(s.RateLimit))
+	}
+	return Any(fn, wcc...)
+}
@@ -0,0 +1,24 @@
+package decor
+
+import (
+	"cmp"
+	"fmt"
+)
+
+// Retries decorator displays number of times bar has been reset by
+// (*mpb.Bar).Reset. Displays nothing until the first reset.
+//
+//	`format` printf compatible verb for value, like "retry %d"
+//
+//	`wcc` optional WC config
+func Retries(format string, wcc ...WC) Decorator {
+	fn := func(s Statistics) string {
+		if s.Retries == 0 {
+			return ""
+		}
+		// translated at render time, as locale could be set later
+		format := cmp.Or(format, Translate("retry %d"))
+		return fmt.Sprintf(format, fixedPoint{int64(s.Retries), 1})
+	}
+	return Any(fn, wcc...)
+}
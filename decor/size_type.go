@@ -5,7 +5,7 @@ package decor
 
 import (
 	"fmt"
-	"strconv"
+	"math"
 )
 
 var (
@@ -21,50 +21,40 @@ const (
 	_iMiB
 	_iGiB
 	_iTiB
+	_iPiB
+	_iEiB
 )
 
-// SizeB1024 named type, which implements fmt.Formatter interface. It
-// adjusts its value according to byte size multiple by 1024 and appends
-// appropriate size marker (KiB, MiB, GiB, TiB).
+// SizeB1024 named type, which implements fmt.Formatter and Unit
+// interfaces. It adjusts its value according to byte size multiple by
+// 1024 and appends appropriate size marker (KiB, MiB, GiB, TiB, PiB, EiB).
 type SizeB1024 int64
 
 func (s SizeB1024) Format(f fmt.State, verb rune) {
-	prec := -1
-	switch verb {
-	case 'f', 'e', 'E':
-		prec = 6 // default prec of fmt.Printf("%f|%e|%E")
-		fallthrough
-	case 'b', 'g', 'G', 'x', 'X':
-		if p, ok := f.Precision(); ok {
-			prec = p
-		}
-	default:
-		verb, prec = 'f', 0
-	}
+	formatUnit(f, verb, s, float64(s))
+}
 
+// Scale implements Unit interface, value is rounded to whole bytes.
+func (SizeB1024) Scale(value float64) (float64, string) {
+	value = math.Round(value)
 	var unit SizeB1024
 	switch {
-	case s < _iKiB:
+	case value < float64(_iKiB):
 		unit = _ib
-	case s < _iMiB:
+	case value < float64(_iMiB):
 		unit = _iKiB
-	case s < _iGiB:
+	case value < float64(_iGiB):
 		unit = _iMiB
-	case s < _iTiB:
+	case value < float64(_iTiB):
 		unit = _iGiB
-	default:
+	case value < float64(_iPiB):
 		unit = _iTiB
+	case value < float64(_iEiB):
+		unit = _iPiB
+	default:
+		unit = _iEiB
 	}
-
-	b := strconv.AppendFloat(make([]byte, 0, 24), float64(s)/float64(unit), byte(verb), prec, 64)
-	if f.Flag(' ') {
-		b = append(b, ' ')
-	}
-	b = append(b, []byte(unit.String())...)
-	_, err := f.Write(b)
-	if err != nil {
-		panic(err)
-	}
+	return value / float64(unit), unit.String()
 }
 
 const (
@@ -73,48 +63,38 @@ const (
 	_MB SizeB1000 = _KB * 1000
 	_GB SizeB1000 = _MB * 1000
 	_TB SizeB1000 = _GB * 1000
+	_PB SizeB1000 = _TB * 1000
+	_EB SizeB1000 = _PB * 1000
 )
 
-// SizeB1000 named type, which implements fmt.Formatter interface. It
-// adjusts its value according to byte size multiple by 1000 and appends
-// appropriate size marker (KB, MB, GB, TB).
+// SizeB1000 named type, which implements fmt.Formatter and Unit
+// interfaces. It adjusts its value according to byte size multiple by
+// 1000 and appends appropriate size marker (KB, MB, GB, TB, PB, EB).
 type SizeB1000 int64
 
 func (s SizeB1000) Format(f fmt.State, verb rune) {
-	prec := -1
-	switch verb {
-	case 'f', 'e', 'E':
-		prec = 6 // default prec of fmt.Printf("%f|%e|%E")
-		fallthrough
-	case 'b', 'g', 'G', 'x', 'X':
-		if p, ok := f.Precision(); ok {
-			prec = p
-		}
-	default:
-		verb, prec = 'f', 0
-	}
+	formatUnit(f, verb, s, float64(s))
+}
 
+// Scale implements Unit interface, value is rounded to whole bytes.
+func (SizeB1000) Scale(value float64) (float64, string) {
+	value = math.Round(value)
 	var unit SizeB1000
 	switch {
-	case s < _KB:
+	case value < float64(_KB):
 		unit = _b
-	case s < _MB:
+	case value < float64(_MB):
 		unit = _KB
-	case s < _GB:
+	case value < float64(_GB):
 		unit = _MB
-	case s < _TB:
+	case value < float64(_TB):
 		unit = _GB
-	default:
+	case value < float64(_PB):
 		unit = _TB
+	case value < float64(_EB):
+		unit = _PB
+	default:
+		unit = _EB
 	}
-
-	b := strconv.AppendFloat(make([]byte, 0, 24), float64(s)/float64(unit), byte(verb), prec, 64)
-	if f.Flag(' ') {
-		b = append(b, ' ')
-	}
-	b = append(b, []byte(unit.String())...)
-	_, err := f.Write(b)
-	if err != nil {
-		panic(err)
-	}
+	return value / float64(unit), unit.String()
 }
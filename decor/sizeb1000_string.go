@@ -13,6 +13,8 @@ func _() {
 	_ = x[_MB-1000000]
 	_ = x[_GB-1000000000]
 	_ = x[_TB-1000000000000]
+	_ = x[_PB-1000000000000000]
+	_ = x[_EB-1000000000000000000]
 }
 
 const (
@@ -21,6 +23,8 @@ const (
 	_SizeB1000_name_2 = "MB"
 	_SizeB1000_name_3 = "GB"
 	_SizeB1000_name_4 = "TB"
+	_SizeB1000_name_5 = "PB"
+	_SizeB1000_name_6 = "EB"
 )
 
 func (i SizeB1000) String() string {
@@ -35,6 +39,10 @@ func (i SizeB1000) String() string {
 		return _SizeB1000_name_3
 	case i == 1000000000000:
 		return _SizeB1000_name_4
+	case i == 1000000000000000:
+		return _SizeB1000_name_5
+	case i == 1000000000000000000:
+		return _SizeB1000_name_6
 	default:
 		return "SizeB1000(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
@@ -13,6 +13,8 @@ func _() {
 	_ = x[_iMiB-1048576]
 	_ = x[_iGiB-1073741824]
 	_ = x[_iTiB-1099511627776]
+	_ = x[_iPiB-1125899906842624]
+	_ = x[_iEiB-1152921504606846976]
 }
 
 const (
@@ -21,6 +23,8 @@ const (
 	_SizeB1024_name_2 = "MiB"
 	_SizeB1024_name_3 = "GiB"
 	_SizeB1024_name_4 = "TiB"
+	_SizeB1024_name_5 = "PiB"
+	_SizeB1024_name_6 = "EiB"
 )
 
 func (i SizeB1024) String() string {
@@ -35,6 +39,10 @@ func (i SizeB1024) String() string {
 		return _SizeB1024_name_3
 	case i == 1099511627776:
 		return _SizeB1024_name_4
+	case i == 1125899906842624:
+		return _SizeB1024_name_5
+	case i == 1152921504606846976:
+		return _SizeB1024_name_6
 	default:
 		return "SizeB1024(" + strconv.FormatInt(int64(i), 10) + ")"
 	}
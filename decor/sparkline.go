@@ -0,0 +1,104 @@
+package decor
+
+import (
+	"cmp"
+	"errors"
+	"math"
+)
+
+var (
+	_ Decorator     = (*sparkline)(nil)
+	_ ResetListener = (*sparkline)(nil)
+)
+
+var sparklineLevels = []rune("▁▂▃▄▅▆▇█")
+
+// Sparkline decorator draws history of speed as a sparkline, one sample
+// per render, e.g. "▁▂▃▅▇▅▃". Speed is read from provided speed decorator,
+// so both agree. The speed decorator has to be added to the same bar, as
+// it's the one which does sampling. Number of kept samples is width set
+// by WC.W, 10 if it's zero. If there is less width available, the oldest
+// samples are not drawn.
+//
+//	`speed` one of [AverageSpeed|NewAverageSpeed|EwmaSpeed|MovingAverageSpeed|EstimatedSpeed|WindowedSpeed] decorators
+//
+//	`top` speed per second drawn as the highest level, if zero or
+//	negative the maximum of the kept samples is used
+//
+//	`wcc` optional WC config
+func Sparkline(speed Decorator, top float64, wcc ...WC) Decorator {
+	estimator, ok := unwrapAs[speedEstimator](speed)
+	if !ok {
+		panic(errors.New("decor: Sparkline requires a built-in speed decorator"))
+	}
+	d := &sparkline{
+		WC:        initWC(wcc...),
+		estimator: estimator,
+		top:       top,
+	}
+	d.history = make([]float64, 0, cmp.Or(d.W, 10))
+	return d
+}
+
+type sparkline struct {
+	WC
+	estimator speedEstimator
+	top       float64
+	history   []float64 // ring buffer, oldest at head once full
+	head      int
+}
+
+func (d *sparkline) Decor(s Statistics) (string, int) {
+	if !s.Completed && !s.Paused {
+		if speed, ok := d.estimator.rate(s); ok {
+			d.add(speed)
+		}
+	}
+	if s.AvailableWidth > 0 && s.AvailableWidth < d.W {
+		// shrink padding as well, so narrow sparkline isn't truncated
+		wc := d.WC
+		wc.W = s.AvailableWidth
+		return wc.Format(d.draw(s.AvailableWidth))
+	}
+	return d.Format(d.draw(s.AvailableWidth))
+}
+
+func (d *sparkline) add(speed float64) {
+	if len(d.history) < cap(d.history) {
+		d.history = append(d.history, speed)
+		return
+	}
+	d.history[d.head] = speed
+	d.head = (d.head + 1) % len(d.history)
+}
+
+// draw draws at most width of the newest samples, all of them if width
+// isn't positive.
+func (d *sparkline) draw(width int) string {
+	n := len(d.history)
+	if width > 0 {
+		n = min(n, width)
+	}
+	skip := len(d.history) - n
+	high := d.top
+	if high <= 0 {
+		for i := skip; i < len(d.history); i++ {
+			high = math.Max(high, d.history[(d.head+i)%len(d.history)])
+		}
+	}
+	runes := make([]rune, 0, n)
+	for i := skip; i < len(d.history); i++ {
+		v := d.history[(d.head+i)%len(d.history)]
+		var level int
+		if high > 0 {
+			level = int(math.Round(v / high * float64(len(sparklineLevels)-1)))
+		}
+		level = min(max(level, 0), len(sparklineLevels)-1)
+		runes = append(runes, sparklineLevels[level])
+	}
+	return string(runes)
+}
+
+func (d *sparkline) OnReset() {
+	d.history, d.head = d.history[:0], 0
+}
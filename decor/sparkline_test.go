@@ -0,0 +1,60 @@
+package decor
+
+import (
+	"testing"
+	"time"
+
+	"github.com/VividCortex/ewma"
+)
+
+type fixedAverage float64
+
+func (a fixedAverage) Add(float64)    {}
+func (a fixedAverage) Value() float64 { return float64(a) }
+func (a fixedAverage) Set(float64)    {}
+
+var _ ewma.MovingAverage = fixedAverage(0)
+
+func TestSparkline(t *testing.T) {
+	estimator := NewRingEstimator(2)
+	speed := EstimatedSpeed(0, "", estimator)
+
+	d := Sparkline(speed, 0, WC{W: 4})
+	stat := Statistics{Total: 1000}
+	var got string
+	for _, current := range []int64{0, 10, 30, 60, 70, 70} {
+		stat.Current = current
+		stat.Updated = stat.Updated.Add(time.Second)
+		speed.Decor(stat)
+		got, _ = d.Decor(stat)
+	}
+	// speeds 10, 20, 30, 10, 0 of which the last 4 are kept
+	if expected := "▆█▃▁"; got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+	// only the newest samples fit into narrow width
+	stat.AvailableWidth = 3
+	if got, _ := d.Decor(stat); got != "█▁▁" {
+		t.Errorf("Expected: %q, got: %q", "█▁▁", got)
+	}
+}
+
+func TestSparklineFixedMax(t *testing.T) {
+	// 1e9/average is speed per second
+	d := Sparkline(MovingAverageSpeed(0, "", fixedAverage(1e8)), 20, WC{W: 3})
+	d.Decor(Statistics{})
+	got, _ := d.Decor(Statistics{})
+	// padded to width of 3
+	if expected := " ▅▅"; got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestSparklinePanicsOnNonSpeed(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected panic")
+		}
+	}()
+	Sparkline(Name("speed"), 0)
+}
@@ -1,9 +1,12 @@
 package decor
 
 import (
+	"cmp"
 	"fmt"
 	"io"
 	"math"
+	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/VividCortex/ewma"
@@ -12,10 +15,25 @@ import (
 var (
 	_ Decorator        = (*movingAverageSpeed)(nil)
 	_ EwmaDecorator    = (*movingAverageSpeed)(nil)
+	_ ResetListener    = (*movingAverageSpeed)(nil)
 	_ Decorator        = (*averageSpeed)(nil)
 	_ AverageDecorator = (*averageSpeed)(nil)
+	_ ResetListener    = (*averageSpeed)(nil)
+	_ Decorator        = (*estimatedSpeed)(nil)
+	_ ResetListener    = (*estimatedSpeed)(nil)
+	_ speedEstimator   = (*movingAverageSpeed)(nil)
+	_ speedEstimator   = (*averageSpeed)(nil)
+	_ speedEstimator   = (*estimatedSpeed)(nil)
 )
 
+// speedEstimator is implemented by built-in speed decorators, so their
+// rate can be shared with Sparkline and speed stats decorators.
+type speedEstimator interface {
+	// rate returns speed per second of unscaled value, it doesn't sample
+	// so it's safe to call by any number of wrappers
+	rate(Statistics) (speed float64, ok bool)
+}
+
 // FmtAsSpeed adds "/s" to the end of the input formatter. To be
 // used with SizeB1000 or SizeB1024 types, for example:
 //
@@ -30,7 +48,7 @@ type speedFormatter struct {
 
 func (s *speedFormatter) Format(st fmt.State, verb rune) {
 	s.Formatter.Format(st, verb)
-	_, err := io.WriteString(st, "/s")
+	_, err := io.WriteString(st, Translate("/s"))
 	if err != nil {
 		panic(err)
 	}
@@ -40,13 +58,13 @@ func (s *speedFormatter) Format(st fmt.State, verb rune) {
 // For this decorator to work correctly you have to measure each iteration's
 // duration and pass it to one of the (*Bar).EwmaIncr... family methods.
 func EwmaSpeed(unit any, format string, age float64, wcc ...WC) Decorator {
-	return MovingAverageSpeed(unit, format, NewThreadSafeMovingAverage(ewma.NewMovingAverage(age)), wcc...)
+	return MovingAverageSpeed(unit, format, newEwma(age), wcc...)
 }
 
 // MovingAverageSpeed decorator relies on MovingAverage implementation
 // to calculate its average.
 //
-//	`unit` one of [0|SizeB1024(0)|SizeB1000(0)]
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
 //
 //	`format` printf compatible verb for value, like "%f" or "%d"
 //
@@ -74,20 +92,29 @@ type movingAverageSpeed struct {
 	producer func(float64) string
 	average  ewma.MovingAverage
 	zDur     time.Duration
+	zReset   atomic.Bool // zDur is cleared by the next EwmaUpdate
+}
+
+func (d *movingAverageSpeed) Decor(s Statistics) (string, int) {
+	if msg, ok := s.stalled(); ok {
+		return d.Format(msg)
+	}
+	speed, _ := d.rate(s)
+	return d.Format(d.producer(speed))
 }
 
-func (d *movingAverageSpeed) Decor(_ Statistics) (string, int) {
-	var str string
+func (d *movingAverageSpeed) rate(s Statistics) (float64, bool) {
 	// ewma implementation may return 0 before accumulating certain number of samples
 	if v := d.average.Value(); v != 0 {
-		str = d.producer(1e9 / v)
-	} else {
-		str = d.producer(0)
+		return s.unscale(1e9 / v), true
 	}
-	return d.Format(str)
+	return 0, false
 }
 
 func (d *movingAverageSpeed) EwmaUpdate(n int64, dur time.Duration) {
+	if d.zReset.Swap(false) {
+		d.zDur = 0
+	}
 	durPerByte := float64(d.zDur+dur) / float64(n)
 	if math.IsInf(durPerByte, 0) || math.IsNaN(durPerByte) {
 		d.zDur += dur
@@ -97,20 +124,27 @@ func (d *movingAverageSpeed) EwmaUpdate(n int64, dur time.Duration) {
 	}
 }
 
+func (d *movingAverageSpeed) OnReset() {
+	resetMovingAverage(d.average)
+	// zDur is owned by EwmaUpdate caller, so it's cleared lazily
+	d.zReset.Store(true)
+}
+
 // AverageSpeed decorator with dynamic unit measure adjustment. It's
-// a wrapper of NewAverageSpeed.
+// a wrapper of NewAverageSpeed with zero start time, i.e. speed is
+// measured since the first update of bar's current.
 func AverageSpeed(unit any, format string, wcc ...WC) Decorator {
-	return NewAverageSpeed(unit, format, time.Now(), wcc...)
+	return NewAverageSpeed(unit, format, time.Time{}, wcc...)
 }
 
 // NewAverageSpeed decorator with dynamic unit measure adjustment and
 // user provided start time.
 //
-//	`unit` one of [0|SizeB1024(0)|SizeB1000(0)]
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
 //
 //	`format` printf compatible verb for value, like "%f" or "%d"
 //
-//	`start` start time
+//	`start` start time, if zero decor.Statistics.Started is used
 //
 //	`wcc` optional WC config
 //
@@ -137,39 +171,157 @@ type averageSpeed struct {
 }
 
 func (d *averageSpeed) Decor(s Statistics) (string, int) {
+	if msg, ok := s.stalled(); ok {
+		return d.Format(msg)
+	}
 	if !s.Completed {
-		speed := float64(s.Current) / float64(time.Since(d.start))
-		d.msg = d.producer(speed * 1e9)
+		speed, _ := d.rate(s)
+		d.msg = d.producer(speed)
 	}
 	return d.Format(d.msg)
 }
 
+func (d *averageSpeed) rate(s Statistics) (float64, bool) {
+	start := cmp.Or(d.start, s.Started)
+	if start.IsZero() {
+		return 0, false
+	}
+	active := s.activeSince(start)
+	if active <= 0 {
+		return 0, false
+	}
+	return s.unscale(float64(s.Current) / float64(active) * 1e9), true
+}
+
 func (d *averageSpeed) AverageAdjust(start time.Time) {
 	d.start = start
 }
 
-func chooseSpeedProducer(unit any, format string) func(float64) string {
-	switch unit.(type) {
-	case SizeB1024:
-		if format == "" {
-			format = "% d"
-		}
-		return func(speed float64) string {
-			return fmt.Sprintf(format, FmtAsSpeed(SizeB1024(math.Round(speed))))
+func (d *averageSpeed) OnReset() {
+	// zero start is restarted by the bar itself
+	if !d.start.IsZero() {
+		d.start = time.Now()
+	}
+}
+
+// EstimatedSpeed decorator relies on Estimator implementation to
+// calculate its rate. Estimator is sampled with bar's current and
+// time of its last update, so it could be shared with EstimatedETA
+// decorator of the same bar.
+//
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`estimator` e.g. one of [NewWindowEstimator|NewRegressionEstimator|NewBlendEstimator|NewRingEstimator]
+//
+//	`wcc` optional WC config
+func EstimatedSpeed(unit any, format string, estimator Estimator, wcc ...WC) Decorator {
+	d := &estimatedSpeed{
+		WC:        initWC(wcc...),
+		producer:  chooseSpeedProducer(unit, format),
+		estimator: NewThreadSafeEstimator(estimator),
+	}
+	return d
+}
+
+type estimatedSpeed struct {
+	WC
+	producer  func(float64) string
+	estimator Estimator
+	atRender  bool
+	msg       string
+}
+
+func (d *estimatedSpeed) Decor(s Statistics) (string, int) {
+	if !s.Completed {
+		sampleEstimator(d.estimator, s, d.atRender)
+		speed, _ := d.rate(s)
+		if msg, ok := s.stalled(); ok {
+			return d.Format(msg)
 		}
-	case SizeB1000:
+		d.msg = d.producer(speed)
+	}
+	return d.Format(d.msg)
+}
+
+func (d *estimatedSpeed) rate(s Statistics) (float64, bool) {
+	rate, ok := d.estimator.Rate(s.activeTime(time.Now()))
+	return s.unscale(rate), ok
+}
+
+func (d *estimatedSpeed) OnReset() {
+	d.estimator.Reset()
+}
+
+// WindowedSpeed decorator samples bar's current on each render, keeping
+// the last `size` samples in a ring buffer. Its rate is measured between
+// the oldest and the newest sample, so it reflects recent progress with
+// no need to call (*Bar).EwmaIncr... family methods.
+//
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`size` number of samples, i.e. window is about size times refresh rate
+//
+//	`wcc` optional WC config
+func WindowedSpeed(unit any, format string, size int, wcc ...WC) Decorator {
+	d := &estimatedSpeed{
+		WC:        initWC(wcc...),
+		producer:  chooseSpeedProducer(unit, format),
+		estimator: NewThreadSafeEstimator(NewRingEstimator(size)),
+		atRender:  true,
+	}
+	return d
+}
+
+// sampleEstimator samples either time of render or time of the last
+// update of bar's current, leaving out time spent paused. If there is no
+// progress since the last pause, time of the last update is shifted back
+// too far, so such sample isn't later than the previous one and is
+// ignored.
+func sampleEstimator(e Estimator, s Statistics, atRender bool) {
+	switch {
+	case atRender:
+		e.Sample(s.Current, s.activeTime(time.Now()))
+	case !s.Updated.IsZero():
+		e.Sample(s.Current, s.activeTime(s.Updated))
+	}
+}
+
+func chooseSpeedProducer(unit any, format string) func(float64) string {
+	if unit, ok := unit.(Unit); ok {
 		if format == "" {
 			format = "% d"
 		}
 		return func(speed float64) string {
-			return fmt.Sprintf(format, FmtAsSpeed(SizeB1000(math.Round(speed))))
-		}
-	default:
-		if format == "" {
-			format = "%f"
+			return fmt.Sprintf(format, FmtAsSpeed(unitValue{unit, speed}))
 		}
-		return func(speed float64) string {
-			return fmt.Sprintf(format, speed)
+	}
+	if format == "" {
+		format = "%f"
+	}
+	return func(speed float64) string {
+		return fmt.Sprintf(format, number(speed))
+	}
+}
+
+// number formats float value, applying separators of global locale to
+// %f and %v verbs.
+type number float64
+
+func (n number) Format(f fmt.State, verb rune) {
+	if (verb == 'f' || verb == 'v') && localizesNumbers() {
+		prec, ok := f.Precision()
+		if !ok {
+			prec = -1 // %v is the smallest number of digits necessary
+			if verb == 'f' {
+				prec = 6 // default prec of fmt.Printf("%f")
+			}
 		}
+		writeNumber(f, strconv.AppendFloat(make([]byte, 0, 24), float64(n), 'f', prec, 64))
+		return
 	}
+	fmt.Fprintf(f, fmt.FormatString(f, verb), float64(n))
 }
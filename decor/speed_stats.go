@@ -0,0 +1,163 @@
+package decor
+
+import (
+	"cmp"
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+	"time"
+)
+
+var (
+	_ Decorator     = (*speedStats)(nil)
+	_ ResetListener = (*speedStats)(nil)
+	_ Decorator     = (*speedSummary)(nil)
+	_ ResetListener = (*speedSummary)(nil)
+)
+
+// PeakSpeed decorator displays the highest speed seen over bar's lifetime,
+// one sample per render. Speed is read from provided speed decorator,
+// which has to be added to the same bar, as it's the one which does
+// sampling.
+//
+//	`speed` one of [AverageSpeed|NewAverageSpeed|EwmaSpeed|MovingAverageSpeed|EstimatedSpeed|WindowedSpeed] decorators
+//
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`wcc` optional WC config
+func PeakSpeed(speed Decorator, unit any, format string, wcc ...WC) Decorator {
+	return newSpeedStats(speed, unit, format, true, wcc...)
+}
+
+// MinSpeed decorator displays the lowest speed seen while bar is active,
+// i.e. neither paused nor stalled, one sample per render. See PeakSpeed
+// for details.
+func MinSpeed(speed Decorator, unit any, format string, wcc ...WC) Decorator {
+	return newSpeedStats(speed, unit, format, false, wcc...)
+}
+
+func newSpeedStats(speed Decorator, unit any, format string, peak bool, wcc ...WC) *speedStats {
+	d := &speedStats{
+		WC:       initWC(wcc...),
+		tracker:  newSpeedTracker(speed),
+		producer: chooseSpeedProducer(unit, format),
+		peak:     peak,
+	}
+	return d
+}
+
+type speedStats struct {
+	WC
+	tracker  *speedTracker
+	producer func(float64) string
+	peak     bool
+}
+
+func (d *speedStats) Decor(s Statistics) (string, int) {
+	d.tracker.track(s)
+	if d.peak {
+		return d.Format(d.producer(d.tracker.peak))
+	}
+	return d.Format(d.producer(d.tracker.min))
+}
+
+func (d *speedStats) OnReset() {
+	d.tracker.reset()
+}
+
+// SpeedSummary decorator displays nothing until bar is completed, then it
+// displays one line summary of peak, minimum and average speed and of
+// total time, e.g. "peak 9.5MiB/s, min 1.2MiB/s, avg 6.0MiB/s, 1m2s".
+// Time spent paused is left out of the average and the total time. See
+// PeakSpeed for details.
+//
+//	`speed` one of [AverageSpeed|NewAverageSpeed|EwmaSpeed|MovingAverageSpeed|EstimatedSpeed|WindowedSpeed] decorators
+//
+//	`unit` either 0 or Unit, e.g. [SizeB1024(0)|SizeB1000(0)|UnitSI("")|UnitBits()|UnitItems("files")]
+//
+//	`format` printf compatible verb for value, like "%f" or "%d"
+//
+//	`style` TimeFormatter, e.g. one of [ET_STYLE_GO|ET_STYLE_HHMMSS|ET_STYLE_DHHMMSS|ET_STYLE_HUMAN]
+//
+//	`wcc` optional WC config
+func SpeedSummary(speed Decorator, unit any, format string, style TimeFormatter, wcc ...WC) Decorator {
+	d := &speedSummary{
+		WC:           initWC(wcc...),
+		tracker:      newSpeedTracker(speed),
+		producer:     chooseSpeedProducer(unit, format),
+		timeProducer: chooseTimeProducer(style),
+	}
+	return d
+}
+
+type speedSummary struct {
+	WC
+	tracker      *speedTracker
+	producer     func(float64) string
+	timeProducer func(time.Duration) string
+	msg          string
+}
+
+func (d *speedSummary) Decor(s Statistics) (string, int) {
+	d.tracker.track(s)
+	if s.Completed && d.msg == "" {
+		var total time.Duration
+		var average float64
+		if !s.Started.IsZero() {
+			total = max(cmp.Or(s.Finished, time.Now()).Sub(s.Started)-s.PausedFor, 0)
+		}
+		if total > 0 {
+			average = s.unscale(float64(s.Current) / total.Seconds())
+		}
+		var b strings.Builder
+		fmt.Fprintf(&b, "%s %s, ", Translate("peak"), d.producer(d.tracker.peak))
+		fmt.Fprintf(&b, "%s %s, ", Translate("min"), d.producer(d.tracker.min))
+		fmt.Fprintf(&b, "%s %s, ", Translate("avg"), d.producer(average))
+		b.WriteString(d.timeProducer(total))
+		d.msg = b.String()
+	}
+	return d.Format(d.msg)
+}
+
+func (d *speedSummary) OnReset() {
+	d.tracker.reset()
+	d.msg = ""
+}
+
+// speedTracker tracks peak and minimum of speed decorator's rate.
+type speedTracker struct {
+	estimator speedEstimator
+	peak, min float64
+	seen      bool
+}
+
+func newSpeedTracker(speed Decorator) *speedTracker {
+	estimator, ok := unwrapAs[speedEstimator](speed)
+	if !ok {
+		panic(errors.New("decor: speed decorator is not a built-in one"))
+	}
+	return &speedTracker{estimator: estimator}
+}
+
+func (t *speedTracker) track(s Statistics) {
+	if s.Completed || s.Aborted || s.Started.IsZero() {
+		return
+	}
+	speed, ok := t.estimator.rate(s)
+	if !ok || s.Paused || s.Stalled || math.IsNaN(speed) || math.IsInf(speed, 0) {
+		return
+	}
+	if !t.seen {
+		t.peak, t.min, t.seen = speed, speed, true
+		return
+	}
+	t.peak = max(t.peak, speed)
+	t.min = min(t.min, speed)
+}
+
+func (t *speedTracker) reset() {
+	t.peak, t.min, t.seen = 0, 0, false
+}
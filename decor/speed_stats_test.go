@@ -0,0 +1,50 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSpeedStats(t *testing.T) {
+	speed := EstimatedSpeed(0, "", NewRingEstimator(2))
+	peak := PeakSpeed(speed, 0, "%.0f")
+	low := MinSpeed(speed, 0, "%.0f")
+	summary := SpeedSummary(speed, 0, "%.0f", ET_STYLE_GO)
+
+	start := time.Date(2024, 5, 1, 12, 0, 0, 0, time.UTC)
+	stat := Statistics{Total: 100, Started: start, Updated: start}
+	for i, current := range []int64{0, 10, 40, 40, 50, 100} {
+		stat.Current = current
+		stat.Updated = start.Add(time.Duration(i) * time.Second)
+		// neither paused nor stalled samples are taken into account
+		stat.Stalled = i == 3
+		speed.Decor(stat)
+		for _, d := range []Decorator{peak, low, summary} {
+			if got, _ := d.Decor(stat); d == summary && got != "" {
+				t.Errorf("Expected empty summary before completion, got: %q", got)
+			}
+		}
+	}
+	if got, _ := peak.Decor(stat); got != "50" {
+		t.Errorf("Expected peak 50, got: %q", got)
+	}
+	if got, _ := low.Decor(stat); got != "10" {
+		t.Errorf("Expected min 10, got: %q", got)
+	}
+
+	stat.Completed = true
+	stat.Finished = start.Add(5 * time.Second)
+	expected := "peak 50, min 10, avg 20, 5s"
+	if got, _ := summary.Decor(stat); got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+}
+
+func TestSpeedStatsPanicsOnNonSpeed(t *testing.T) {
+	defer func() {
+		if _, ok := recover().(error); !ok {
+			t.Error("Expected panic with error")
+		}
+	}()
+	PeakSpeed(Name("speed"), 0, "")
+}
@@ -276,3 +276,19 @@ func TestAverageSpeedSizeB1000(t *testing.T) {
 		})
 	}
 }
+
+func TestSpeedStalled(t *testing.T) {
+	stat := Statistics{Current: 10, Stalled: true, StalledFor: 30 * time.Second}
+	for _, d := range []Decorator{
+		AverageSpeed(0, "%.1f"),
+		EwmaSpeed(0, "%.1f", 30),
+		WindowedSpeed(0, "%.1f", 10),
+		AverageETA(ET_STYLE_GO),
+		EwmaETA(ET_STYLE_GO, 30),
+		WindowedETA(ET_STYLE_GO, 10, nil),
+	} {
+		if got, _ := d.Decor(stat); got != "stalled 30s" {
+			t.Errorf("%T: expected %q, got: %q", d, "stalled 30s", got)
+		}
+	}
+}
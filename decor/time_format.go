@@ -0,0 +1,121 @@
+package decor
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+var (
+	_ TimeFormatter = ET_STYLE_GO
+	_ TimeFormatter = TimeFormatterFunc(nil)
+)
+
+const day = 24 * time.Hour
+
+// TimeFormatter interface. Implementers could be passed into Elapsed and
+// ETA decorators in order to format their output, which is normalized by
+// TimeNormalizer first if any.
+type TimeFormatter interface {
+	FormatTime(time.Duration) string
+}
+
+// TimeFormatterFunc is function type adapter to convert function
+// into TimeFormatter.
+type TimeFormatterFunc func(time.Duration) string
+
+func (f TimeFormatterFunc) FormatTime(d time.Duration) string {
+	return f(d)
+}
+
+// FormatTime implements TimeFormatter interface.
+func (style TimeStyle) FormatTime(d time.Duration) string {
+	hours := int64(d / time.Hour)
+	minutes := int64(d/time.Minute) % 60
+	seconds := int64(d/time.Second) % 60
+	switch style {
+	case ET_STYLE_HHMMSS:
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+	case ET_STYLE_HHMM:
+		return fmt.Sprintf("%02d:%02d", hours, minutes)
+	case ET_STYLE_MMSS:
+		if hours > 0 {
+			return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+		}
+		return fmt.Sprintf("%02d:%02d", minutes, seconds)
+	case ET_STYLE_DHHMMSS:
+		if days := int64(d / day); days > 0 {
+			return fmt.Sprintf("%dd %02d:%02d:%02d", days, hours%24, minutes, seconds)
+		}
+		return fmt.Sprintf("%02d:%02d:%02d", hours, minutes, seconds)
+	case ET_STYLE_COMPACT:
+		return formatCompact(d)
+	case ET_STYLE_MILLIS:
+		millis := int64(d/time.Millisecond) % 1000
+		return fmt.Sprintf("%02d:%02d:%02d.%03d", hours, minutes, seconds, millis)
+	case ET_STYLE_HUMAN:
+		return humanize(d)
+	default:
+		return localizeDuration(d.Truncate(time.Second).String())
+	}
+}
+
+func formatCompact(d time.Duration) string {
+	d = d.Truncate(time.Second)
+	var b []byte
+	if d < 0 {
+		b = append(b, '-')
+		d = -d
+	}
+	if d < time.Second {
+		return localizeDuration("0s")
+	}
+	for _, u := range [...]struct {
+		dur  time.Duration
+		name string
+	}{
+		{day, "d"},
+		{time.Hour, "h"},
+		{time.Minute, "m"},
+		{time.Second, "s"},
+	} {
+		if n := d / u.dur; n != 0 {
+			b = strconv.AppendInt(b, int64(n), 10)
+			b = append(b, Translate(u.name)...)
+			d -= n * u.dur
+		}
+	}
+	return string(b)
+}
+
+func humanize(d time.Duration) string {
+	round := func(unit time.Duration) int64 {
+		return int64((d + unit/2) / unit)
+	}
+	if d < time.Minute {
+		return Translate("less than a minute")
+	}
+	if n := round(time.Minute); n < 60 {
+		if n == 1 {
+			return Translate("about a minute")
+		}
+		return fmt.Sprintf(Translate("about %d minutes"), n)
+	}
+	if n := round(time.Hour); n < 24 {
+		if n == 1 {
+			return Translate("about an hour")
+		}
+		return fmt.Sprintf(Translate("about %d hours"), n)
+	}
+	if n := round(day); n > 1 {
+		return fmt.Sprintf(Translate("about %d days"), n)
+	}
+	return Translate("about a day")
+}
+
+func chooseTimeProducer(style TimeFormatter) func(time.Duration) string {
+	if style == nil {
+		style = ET_STYLE_GO
+	}
+	return style.FormatTime
+}
@@ -0,0 +1,52 @@
+package decor
+
+import (
+	"testing"
+	"time"
+)
+
+func TestTimeStyleFormatTime(t *testing.T) {
+	d := 70*time.Hour + 2*time.Minute + 3*time.Second + 456*time.Millisecond
+	cases := map[string]struct {
+		style    TimeStyle
+		value    time.Duration
+		expected string
+	}{
+		"go":               {ET_STYLE_GO, d, "70h2m3s"},
+		"hhmmss":           {ET_STYLE_HHMMSS, d, "70:02:03"},
+		"hhmm":             {ET_STYLE_HHMM, d, "70:02"},
+		"mmss":             {ET_STYLE_MMSS, d, "70:02:03"},
+		"mmss no hours":    {ET_STYLE_MMSS, 3 * time.Minute, "03:00"},
+		"dhhmmss":          {ET_STYLE_DHHMMSS, d, "2d 22:02:03"},
+		"dhhmmss no days":  {ET_STYLE_DHHMMSS, time.Hour, "01:00:00"},
+		"compact":          {ET_STYLE_COMPACT, 3*time.Minute + 12*time.Second, "3m12s"},
+		"compact days":     {ET_STYLE_COMPACT, d, "2d22h2m3s"},
+		"compact zero":     {ET_STYLE_COMPACT, 0, "0s"},
+		"compact negative": {ET_STYLE_COMPACT, -(time.Minute + 5*time.Second), "-1m5s"},
+		"millis":           {ET_STYLE_MILLIS, d, "70:02:03.456"},
+		"human seconds":    {ET_STYLE_HUMAN, 30 * time.Second, "less than a minute"},
+		"human minute":     {ET_STYLE_HUMAN, 80 * time.Second, "about a minute"},
+		"human minutes":    {ET_STYLE_HUMAN, 5*time.Minute + 10*time.Second, "about 5 minutes"},
+		"human rounds up":  {ET_STYLE_HUMAN, 59*time.Minute + 50*time.Second, "about an hour"},
+		"human hours":      {ET_STYLE_HUMAN, 3 * time.Hour, "about 3 hours"},
+		"human day":        {ET_STYLE_HUMAN, 25 * time.Hour, "about a day"},
+		"human days":       {ET_STYLE_HUMAN, d, "about 3 days"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			if got := tc.style.FormatTime(tc.value); got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestTimeFormatterFunc(t *testing.T) {
+	f := TimeFormatterFunc(func(d time.Duration) string {
+		return d.Round(time.Millisecond).String()
+	})
+	d := NewElapsed(f, time.Time{})
+	if got, _ := d.Decor(Statistics{}); got != "0s" {
+		t.Errorf("Expected: %q, got: %q", "0s", got)
+	}
+}
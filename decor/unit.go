@@ -0,0 +1,113 @@
+package decor
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+)
+
+var (
+	_ Unit          = SizeB1024(0)
+	_ Unit          = SizeB1000(0)
+	_ Unit          = siUnit{}
+	_ Unit          = itemsUnit("")
+	_ fmt.Formatter = unitValue{}
+)
+
+// Unit interface. Implementations can be passed as `unit` param of
+// counter and speed decorators, e.g. Counters, Total, Current,
+// InvertedCurrent, AverageSpeed, EwmaSpeed, MovingAverageSpeed.
+// Built-in implementations are:
+//
+//	SizeB1024(0)
+//	SizeB1000(0)
+//	UnitSI(suffix)
+//	UnitBits()
+//	UnitItems(noun)
+type Unit interface {
+	// Scale returns value adjusted to appropriate magnitude and suffix
+	// to be appended to it, like 1.5 and "KiB" for 1536.
+	Scale(value float64) (scaled float64, suffix string)
+}
+
+// UnitSI returns Unit which adjusts value by SI prefixes (k, M, G, T, P,
+// E) and appends provided suffix. For example UnitSI("") with format
+// "%.1f" outputs "1.5k" and UnitSI("B") outputs "1.5kB".
+func UnitSI(suffix string) Unit {
+	return siUnit{suffix: suffix, multiplier: 1}
+}
+
+// UnitBits returns Unit which converts bytes into bits and adjusts them
+// by SI prefixes. For example speed decorator with format "% .1f"
+// outputs "12.5 Mbit/s".
+func UnitBits() Unit {
+	return siUnit{suffix: "bit", multiplier: 8}
+}
+
+// UnitItems returns Unit which appends provided noun to unadjusted
+// value. For example UnitItems("files") with format "% d" outputs
+// "12 files".
+func UnitItems(noun string) Unit {
+	return itemsUnit(noun)
+}
+
+type siUnit struct {
+	suffix     string
+	multiplier float64
+}
+
+func (u siUnit) Scale(value float64) (float64, string) {
+	const prefixes = "kMGTPE"
+	value *= u.multiplier
+	var prefix string
+	for i := 0; i < len(prefixes) && math.Abs(value) >= 1000; i++ {
+		value /= 1000
+		prefix = prefixes[i : i+1]
+	}
+	return value, prefix + u.suffix
+}
+
+type itemsUnit string
+
+func (u itemsUnit) Scale(value float64) (float64, string) {
+	return value, string(u)
+}
+
+// unitValue formats value in provided unit.
+type unitValue struct {
+	unit  Unit
+	value float64
+}
+
+func (v unitValue) Format(f fmt.State, verb rune) {
+	formatUnit(f, verb, v.unit, v.value)
+}
+
+func formatUnit(f fmt.State, verb rune, unit Unit, value float64) {
+	prec := -1
+	switch verb {
+	case 'f', 'e', 'E':
+		prec = 6 // default prec of fmt.Printf("%f|%e|%E")
+		fallthrough
+	case 'b', 'g', 'G', 'x', 'X':
+		if p, ok := f.Precision(); ok {
+			prec = p
+		}
+	default:
+		verb, prec = 'f', 0
+	}
+
+	value, suffix := unit.Scale(value)
+	b := strconv.AppendFloat(make([]byte, 0, 24), value, byte(verb), prec, 64)
+	if verb == 'f' {
+		b = localizeNumber(b)
+	}
+	if f.Flag(' ') && suffix != "" {
+		b = append(b, ' ')
+	}
+	b = append(b, Translate(suffix)...)
+	_, err := f.Write(b)
+	if err != nil {
+		panic(err)
+	}
+}
@@ -0,0 +1,62 @@
+package decor
+
+import (
+	"fmt"
+	"testing"
+)
+
+type kelvin struct{}
+
+func (kelvin) Scale(value float64) (float64, string) {
+	return value, "K"
+}
+
+func TestUnits(t *testing.T) {
+	cases := map[string]struct {
+		unit     Unit
+		value    float64
+		verb     string
+		expected string
+	}{
+		"SI %d":         {UnitSI(""), 1500, "%d", "2k"},
+		"SI %.1f":       {UnitSI(""), 1500, "%.1f", "1.5k"},
+		"SI % .1f":      {UnitSI("rows"), 2500000, "% .1f", "2.5 Mrows"},
+		"SI small % d":  {UnitSI(""), 12, "% d", "12"},
+		"bits % .1f":    {UnitBits(), 1562500, "% .1f", "12.5 Mbit"},
+		"items % d":     {UnitItems("files"), 12, "% d", "12 files"},
+		"items %d":      {UnitItems("files"), 12000, "%d", "12000files"},
+		"PiB %.1f":      {SizeB1024(0), 1.5 * (1 << 50), "%.1f", "1.5PiB"},
+		"EiB %d":        {SizeB1024(0), 2 * (1 << 60), "%d", "2EiB"},
+		"PB %d":         {SizeB1000(0), 3e15, "%d", "3PB"},
+		"EB % .1f":      {SizeB1000(0), 4.5e18, "% .1f", "4.5 EB"},
+		"user defined":  {kelvin{}, 273.15, "% .2f", "273.15 K"},
+		"user negative": {kelvin{}, -1, "%d", "-1K"},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			got := fmt.Sprintf(tc.verb, unitValue{tc.unit, tc.value})
+			if got != tc.expected {
+				t.Errorf("Expected: %q, got: %q", tc.expected, got)
+			}
+		})
+	}
+}
+
+func TestUnitDecorators(t *testing.T) {
+	stat := Statistics{Current: 12, Total: 30}
+
+	got, _ := Counters(UnitItems("files"), "").Decor(stat)
+	if expected := "12 files / 30 files"; got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+
+	producer := chooseSpeedProducer(UnitBits(), "% .1f")
+	if got, expected := producer(1562500), "12.5 Mbit/s"; got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+
+	producer = chooseSpeedProducer(kelvin{}, "%d")
+	if got, expected := producer(3), "3K/s"; got != expected {
+		t.Errorf("Expected: %q, got: %q", expected, got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"fmt"
 	"testing"
+	"time"
 	"unicode/utf8"
 )
 
@@ -1607,3 +1608,75 @@ func TestDrawMeta(t *testing.T) {
 		})
 	}
 }
+
+func TestDrawPaused(t *testing.T) {
+	t.Parallel()
+	testSuite := []struct {
+		filler  BarFiller
+		name    string
+		paused  bool
+		stalled bool
+		want    string
+	}{
+		{
+			filler: BarStyle().PausedTip("|").Build(),
+			name:   "running",
+			want:   "[=====>-----]",
+		},
+		{
+			filler: BarStyle().PausedTip("|").Build(),
+			name:   "paused tip",
+			paused: true,
+			want:   "[=====|-----]",
+		},
+		{
+			filler: BarStyle().PausedMeta(func(s string) string { return "<" + s + ">" }).Build(),
+			name:   "paused meta",
+			paused: true,
+			want:   "<[=====>-----]>",
+		},
+		{
+			filler:  BarStyle().StalledMeta(func(s string) string { return "!" + s + "!" }).Build(),
+			name:    "stalled meta",
+			stalled: true,
+			want:    "![=====>-----]!",
+		},
+		{
+			filler: BarStyle().
+				PausedMeta(func(s string) string { return "<" + s + ">" }).
+				StalledMeta(func(s string) string { return "!" + s + "!" }).Build(),
+			name:    "paused and stalled",
+			paused:  true,
+			stalled: true,
+			want:    "<[=====>-----]>",
+		},
+	}
+
+	for _, tc := range testSuite {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+			var tmpBuf bytes.Buffer
+			ps := pState{}
+			s := ps.makeBarState(100, tc.filler)
+			s.current = 50
+			s.trimSpace = true
+			s.paused = tc.paused
+			if tc.stalled {
+				s.stallTimeout = time.Second
+				s.watched = time.Now().Add(-time.Minute)
+			}
+			r, err := s.draw(s.newStatistics(13))
+			if err != nil {
+				t.Fatalf("draw error: %s", err.Error())
+			}
+			_, err = tmpBuf.ReadFrom(r)
+			if err != nil {
+				t.Fatalf("read from r error: %s", err.Error())
+			}
+			got := string(bytes.TrimSuffix(tmpBuf.Bytes(), []byte("\n")))
+			if got != tc.want {
+				t.Errorf("want: %q, got: %q", tc.want, got)
+			}
+		})
+	}
+}
@@ -33,6 +33,7 @@ type pushData struct {
 
 type renderData struct {
 	width   int
+	stats   *decor.ContainerStatistics
 	seqCh   chan<- iter.Seq[*Bar]
 	offload <-chan heapRequest
 }
@@ -87,7 +88,7 @@ func (m heapManager) run(pwg *sync.WaitGroup, shutdown <-chan any, depleteHeap c
 			var pushQ []heapRequest
 			data := req.data.(renderData)
 			for _, b := range bHeap {
-				go b.render(data.width)
+				go b.render(data.width, data.stats)
 			}
 			data.seqCh <- func(yield func(*Bar) bool) {
 				for bHeap.Len() != 0 {
@@ -151,13 +152,14 @@ func (m heapManager) push(bar *Bar, sync bool, offload chan<- heapRequest) {
 	}
 }
 
-func (m heapManager) render(width int, offload <-chan heapRequest) iter.Seq[*Bar] {
+func (m heapManager) render(width int, stats *decor.ContainerStatistics, offload <-chan heapRequest) iter.Seq[*Bar] {
 	if offload == nil {
 		panic(errors.New("expected non nil offload chan heapRequest"))
 	}
 	seqCh := make(chan iter.Seq[*Bar], 1)
 	m <- heapRequest{cmd: h_render, data: renderData{
 		width:   width,
+		stats:   stats,
 		seqCh:   seqCh,
 		offload: offload,
 	}}
@@ -0,0 +1,185 @@
+package mpb
+
+import (
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/vbauerster/mpb/v8/decor"
+)
+
+// HTTPBarOption produces BarOption for a bar constructed by either
+// NewTransport or NewHandler. A new option is requested for each bar,
+// which makes it the right place to construct decorators. `name` is
+// derived from request's URL and `upload` reports whether the bar tracks
+// request's body as opposed to response's body.
+type HTTPBarOption func(name string, upload bool) BarOption
+
+// HTTPBarName prepends decor.Name decorator with name derived from
+// request's URL.
+func HTTPBarName(wcc ...decor.WC) HTTPBarOption {
+	if len(wcc) == 0 {
+		wcc = []decor.WC{{C: decor.DindentRight | decor.DextraSpace}}
+	}
+	return func(name string, _ bool) BarOption {
+		return PrependDecorators(decor.Name(name, wcc...))
+	}
+}
+
+// NewTransport wraps base http.RoundTripper so each request body upload
+// and each response body download is tracked by its own bar. A bar is
+// sized by ContentLength (spinner filler is used if it's unknown), has no
+// decorators unless provided by options, e.g. HTTPBarName, and is aborted
+// with transport error as its cause. A bar is constructed on the first
+// read, so body which is never read isn't tracked. Response of redirect,
+// which is followed by http.Client, isn't tracked and bar of its request
+// body is removed, so only the final request and response are tracked.
+// Response body must be read until EOF and closed as usual, otherwise bar
+// is aborted on close. If base is nil http.DefaultTransport is used. Once
+// `p` is done requests are passed through untracked.
+func NewTransport(p *Progress, base http.RoundTripper, options ...HTTPBarOption) http.RoundTripper {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{p, base, options}
+}
+
+// NewHandler wraps http.Handler so each request body received by the
+// server is tracked by its own bar. See NewTransport for details.
+func NewHandler(p *Progress, h http.Handler, options ...HTTPBarOption) http.Handler {
+	return &handler{p, h, options}
+}
+
+type transport struct {
+	p       *Progress
+	base    http.RoundTripper
+	options []HTTPBarOption
+}
+
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	name := req.URL.Redacted()
+	var upload *trackedBody
+	if req.Body != nil && req.Body != http.NoBody {
+		upload = trackBody(t.p, req.Body, req.ContentLength, name, true, t.options)
+		req = req.Clone(req.Context())
+		req.Body = upload
+	}
+	resp, err := t.base.RoundTrip(req)
+	if err != nil {
+		if bar := upload.untrack(); bar != nil {
+			bar.abort(false, err)
+		}
+		return nil, err
+	}
+	if isRedirect(resp) {
+		if bar := upload.untrack(); bar != nil {
+			bar.Abort(true)
+		}
+		return resp, nil
+	}
+	if resp.Body != nil && resp.Body != http.NoBody {
+		resp.Body = trackBody(t.p, resp.Body, resp.ContentLength, name, false, t.options)
+	}
+	return resp, nil
+}
+
+// isRedirect reports whether resp is going to be followed by http.Client.
+func isRedirect(resp *http.Response) bool {
+	switch resp.StatusCode {
+	case http.StatusMovedPermanently, http.StatusFound, http.StatusSeeOther,
+		http.StatusTemporaryRedirect, http.StatusPermanentRedirect:
+		return resp.Header.Get("Location") != ""
+	}
+	return false
+}
+
+type handler struct {
+	p       *Progress
+	h       http.Handler
+	options []HTTPBarOption
+}
+
+func (h *handler) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if req.Body != nil && req.Body != http.NoBody {
+		body := trackBody(h.p, req.Body, req.ContentLength, req.URL.Redacted(), true, h.options)
+		req = req.Clone(req.Context())
+		req.Body = body
+		// abort if handler didn't read the body till EOF
+		defer func() {
+			_ = body.Close()
+		}()
+	}
+	h.h.ServeHTTP(w, req)
+}
+
+// trackedBody constructs its bar on the first read.
+type trackedBody struct {
+	io.ReadCloser
+	once  sync.Once
+	track func() (*Bar, io.ReadCloser)
+	bar   *Bar
+	proxy io.ReadCloser
+}
+
+func (x *trackedBody) Read(p []byte) (int, error) {
+	x.once.Do(func() {
+		x.bar, x.proxy = x.track()
+	})
+	if x.bar == nil {
+		return x.ReadCloser.Read(p)
+	}
+	n, err := x.proxy.Read(p)
+	switch {
+	case err == io.EOF:
+		// total might have been inaccurate or unknown at all
+		x.bar.SetTotal(-1, true)
+	case err != nil:
+		x.bar.abort(false, err)
+	}
+	return n, err
+}
+
+func (x *trackedBody) Close() error {
+	bar := x.untrack()
+	err := x.ReadCloser.Close()
+	if bar != nil {
+		// no-op if EOF has been reached already
+		bar.abort(false, err)
+	}
+	return err
+}
+
+// untrack prevents bar construction, if it hasn't been constructed yet,
+// and returns the bar if any. It's safe to call on nil x.
+func (x *trackedBody) untrack() *Bar {
+	if x == nil {
+		return nil
+	}
+	x.once.Do(func() {})
+	return x.bar
+}
+
+func trackBody(p *Progress, body io.ReadCloser, size int64, name string, upload bool, options []HTTPBarOption) *trackedBody {
+	track := func() (*Bar, io.ReadCloser) {
+		var builder BarFillerBuilder = barStyleComposer
+		if size <= 0 {
+			builder, size = spinnerStyleComposer, 0
+		}
+		opts := make([]BarOption, 0, len(options))
+		for _, option := range options {
+			if option != nil {
+				opts = append(opts, option(name, upload))
+			}
+		}
+		bar, err := p.Add(size, builder.Build(), opts...)
+		if err != nil {
+			return nil, nil
+		}
+		pr, err := bar.ProxyReader(body)
+		if err != nil {
+			return nil, nil
+		}
+		return bar, pr
+	}
+	return &trackedBody{ReadCloser: body, track: track}
+}
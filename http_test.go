@@ -0,0 +1,176 @@
+package mpb_test
+
+import (
+	"bytes"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+)
+
+func TestTransportDownload(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, content)
+	}))
+	defer srv.Close()
+
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(120),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+	)
+	client := &http.Client{
+		Transport: mpb.NewTransport(p, nil, mpb.HTTPBarName(), func(_ string, upload bool) mpb.BarOption {
+			if upload {
+				t.Error("Unexpected upload bar")
+			}
+			return mpb.AppendDecorators(decor.CountersNoUnit("%d/%d"))
+		}),
+	}
+	resp, err := client.Get(srv.URL + "/file")
+	if err != nil {
+		t.Fatal(err)
+	}
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	p.Wait()
+
+	if got := string(body); got != content {
+		t.Errorf("Expected content: %s, got: %s\n", content, got)
+	}
+	if !strings.Contains(buf.String(), srv.URL+"/file") {
+		t.Errorf("Expected bar name %q in output, got: %q", srv.URL+"/file", buf.String())
+	}
+}
+
+func TestTransportUpload(t *testing.T) {
+	var received []byte
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer srv.Close()
+
+	var uploads int
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	client := &http.Client{
+		Transport: mpb.NewTransport(p, nil, func(_ string, upload bool) mpb.BarOption {
+			if upload {
+				uploads++
+			}
+			return nil
+		}),
+	}
+	resp, err := client.Post(srv.URL, "text/plain", strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	p.Wait()
+
+	if uploads != 1 {
+		t.Errorf("Expected 1 upload bar, got: %d", uploads)
+	}
+	if got := string(received); got != content {
+		t.Errorf("Expected content: %s, got: %s\n", content, got)
+	}
+}
+
+func TestTransportRedirect(t *testing.T) {
+	mux := http.NewServeMux()
+	mux.Handle("/old", http.RedirectHandler("/new", http.StatusFound))
+	mux.HandleFunc("/new", func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, content)
+	})
+	srv := httptest.NewServer(mux)
+	defer srv.Close()
+
+	var names []string
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	client := &http.Client{
+		Transport: mpb.NewTransport(p, nil, func(name string, _ bool) mpb.BarOption {
+			names = append(names, name)
+			return nil
+		}),
+	}
+	resp, err := client.Get(srv.URL + "/old")
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.Copy(io.Discard, resp.Body)
+	_ = resp.Body.Close()
+	p.Wait()
+
+	if len(names) != 1 || names[0] != srv.URL+"/new" {
+		t.Errorf("Expected single bar of the final response, got: %q", names)
+	}
+}
+
+func TestTransportUnreadBody(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		_, _ = io.WriteString(w, content)
+	}))
+	defer srv.Close()
+
+	var bars int
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	client := &http.Client{
+		Transport: mpb.NewTransport(p, nil, func(string, bool) mpb.BarOption {
+			bars++
+			return nil
+		}),
+	}
+	resp, err := client.Get(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	p.Wait()
+
+	if bars != 0 {
+		t.Errorf("Expected no bar for body which isn't read, got: %d", bars)
+	}
+}
+
+func TestTransportError(t *testing.T) {
+	srv := httptest.NewServer(http.NotFoundHandler())
+	srv.Close()
+
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	client := &http.Client{Transport: mpb.NewTransport(p, nil)}
+	_, err := client.Post(srv.URL, "text/plain", strings.NewReader(content))
+	if err == nil {
+		t.Fatal("Expected transport error")
+	}
+	p.Wait() // must not hang
+}
+
+func TestHandler(t *testing.T) {
+	var received []byte
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	srv := httptest.NewServer(mpb.NewHandler(p, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received, _ = io.ReadAll(r.Body)
+		w.WriteHeader(http.StatusNoContent)
+	})))
+	defer srv.Close()
+
+	resp, err := http.Post(srv.URL, "text/plain", strings.NewReader(content))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_ = resp.Body.Close()
+	p.Wait()
+
+	if got := string(received); got != content {
+		t.Errorf("Expected content: %s, got: %s\n", content, got)
+	}
+}
@@ -0,0 +1,178 @@
+package internal
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+)
+
+// Limiter is a token bucket rate limiter, which refills at `limit` tokens
+// per second with bucket size of `limit` tokens. Zero or negative limit
+// means no limit at all. A nil *Limiter is valid and is never limited.
+type Limiter struct {
+	mu     sync.Mutex
+	limit  float64
+	tokens float64
+	last   time.Time
+}
+
+// NewLimiter creates Limiter with provided limit of tokens per second.
+func NewLimiter(limit int64) *Limiter {
+	return &Limiter{limit: float64(max(limit, 0))}
+}
+
+// Limit returns current limit of tokens per second.
+func (l *Limiter) Limit() int64 {
+	if l == nil {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return int64(l.limit)
+}
+
+// SetLimit changes limit, tokens already accumulated are preserved up to
+// the new bucket size.
+func (l *Limiter) SetLimit(limit int64) {
+	if l == nil {
+		return
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if limit <= 0 {
+		l.limit, l.tokens, l.last = 0, 0, time.Time{}
+		return
+	}
+	if l.limit > 0 {
+		l.advance(time.Now())
+	}
+	l.limit = float64(limit)
+	l.tokens = min(l.tokens, l.limit)
+}
+
+// Burst returns max number of tokens which is reasonable to consume at
+// once. Zero means no limit.
+func (l *Limiter) Burst() int {
+	return int(l.Limit())
+}
+
+// Reserve consumes n tokens and returns duration to wait until these
+// tokens are actually available.
+func (l *Limiter) Reserve(n int) time.Duration {
+	if l == nil || n <= 0 {
+		return 0
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if l.limit <= 0 {
+		return 0
+	}
+	l.advance(time.Now())
+	l.tokens -= float64(n)
+	if l.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-l.tokens / l.limit * float64(time.Second))
+}
+
+// Wait consumes n tokens and blocks until these tokens are available or
+// ctx is done.
+func (l *Limiter) Wait(ctx context.Context, n int) error {
+	d := l.Reserve(n)
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return context.Cause(ctx)
+	}
+}
+
+func (l *Limiter) advance(now time.Time) {
+	if l.last.IsZero() {
+		l.tokens = l.limit
+	} else {
+		l.tokens = min(l.tokens+now.Sub(l.last).Seconds()*l.limit, l.limit)
+	}
+	l.last = now
+}
+
+// Pool shares limit of tokens per second evenly among limiters, which
+// have joined it. Share of each limiter is adjusted as limiters join and
+// leave. Zero or negative limit means no limit at all. A nil *Pool is
+// valid and is never limited.
+type Pool struct {
+	mu      sync.Mutex
+	limit   int64
+	members []*Limiter
+}
+
+// NewPool creates Pool with provided limit of tokens per second.
+func NewPool(limit int64) *Pool {
+	return &Pool{limit: max(limit, 0)}
+}
+
+// Limit returns current limit of tokens per second of the whole pool.
+func (p *Pool) Limit() int64 {
+	if p == nil {
+		return 0
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	return p.limit
+}
+
+// SetLimit changes limit of the whole pool.
+func (p *Pool) SetLimit(limit int64) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.limit = max(limit, 0)
+	p.rebalance()
+}
+
+// Join returns a new limiter, which gets its share of the pool's limit.
+func (p *Pool) Join() *Limiter {
+	if p == nil {
+		return nil
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	l := NewLimiter(0)
+	p.members = append(p.members, l)
+	p.rebalance()
+	return l
+}
+
+// Leave gives share of l back to the rest of the pool.
+func (p *Pool) Leave(l *Limiter) {
+	if p == nil {
+		return
+	}
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if i := slices.Index(p.members, l); i != -1 {
+		p.members = slices.Delete(p.members, i, i+1)
+		p.rebalance()
+	}
+}
+
+func (p *Pool) rebalance() {
+	if len(p.members) == 0 {
+		return
+	}
+	share := p.limit / int64(len(p.members))
+	if p.limit > 0 {
+		share = max(share, 1)
+	}
+	for _, l := range p.members {
+		l.SetLimit(share)
+	}
+}
@@ -0,0 +1,79 @@
+package internal
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestLimiterReserve(t *testing.T) {
+	l := NewLimiter(1000)
+
+	if d := l.Reserve(1000); d != 0 {
+		t.Errorf("Expected no delay on full bucket, got: %v", d)
+	}
+	if d := l.Reserve(500); d < 400*time.Millisecond || d > 500*time.Millisecond {
+		t.Errorf("Expected delay about 500ms, got: %v", d)
+	}
+
+	l.SetLimit(0)
+	if d := l.Reserve(1 << 20); d != 0 {
+		t.Errorf("Expected no delay when unlimited, got: %v", d)
+	}
+}
+
+func TestLimiterNil(t *testing.T) {
+	var l *Limiter
+	l.SetLimit(100)
+	if limit := l.Limit(); limit != 0 {
+		t.Errorf("Expected limit: 0, got: %d", limit)
+	}
+	if err := l.Wait(context.Background(), 1<<20); err != nil {
+		t.Errorf("Expected nil error, got: %v", err)
+	}
+}
+
+func TestLimiterWaitCanceled(t *testing.T) {
+	l := NewLimiter(10)
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := l.Wait(ctx, 100); err != context.Canceled {
+		t.Errorf("Expected %v, got: %v", context.Canceled, err)
+	}
+}
+
+func TestPoolShare(t *testing.T) {
+	p := NewPool(1000)
+
+	a := p.Join()
+	if limit := a.Limit(); limit != 1000 {
+		t.Errorf("Expected limit: 1000, got: %d", limit)
+	}
+	b := p.Join()
+	for _, l := range []*Limiter{a, b} {
+		if limit := l.Limit(); limit != 500 {
+			t.Errorf("Expected limit: 500, got: %d", limit)
+		}
+	}
+	p.Leave(a)
+	if limit := b.Limit(); limit != 1000 {
+		t.Errorf("Expected limit: 1000, got: %d", limit)
+	}
+	p.SetLimit(0)
+	if limit := b.Limit(); limit != 0 {
+		t.Errorf("Expected limit: 0, got: %d", limit)
+	}
+}
+
+func TestPoolNil(t *testing.T) {
+	var p *Pool
+	p.SetLimit(100)
+	if limit := p.Limit(); limit != 0 {
+		t.Errorf("Expected limit: 0, got: %d", limit)
+	}
+	l := p.Join()
+	if err := l.Wait(context.Background(), 1<<20); err != nil {
+		t.Errorf("Expected nil error, got: %v", err)
+	}
+	p.Leave(l)
+}
@@ -10,11 +10,13 @@ import (
 	"math"
 	"os"
 	"slices"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/vbauerster/cupwriter"
 	"github.com/vbauerster/mpb/v8/decor"
+	"github.com/vbauerster/mpb/v8/internal"
 )
 
 const defaultRefreshRate = 150 * time.Millisecond
@@ -41,31 +43,46 @@ type Progress struct {
 	interceptIO  chan func(io.Writer)
 	renderReq    chan time.Time
 	done         chan struct{}
+	limiter      *internal.Pool
+	tasks        *taskGroup
 	noRenderMode bool
 }
 
 // pState holds bars in its priorityQueue, it gets passed to (*Progress).serve monitor goroutine.
 type pState struct {
-	hm          heapManager
-	idCount     int
-	popPriority int
+	hm           heapManager
+	idCount      int
+	popPriority  int
+	activeCount  int
+	pendingBars  []*Bar
+	taskCount    int
+	pendingTasks []*Bar                     // tasks waiting for a slot, see WithTaskLimit
+	stats        *decor.ContainerStatistics // as of the last render
+	retired      decor.ContainerStatistics  // bars which have left the heap
 
 	// following are provided/overrode by user
 	uwg              *sync.WaitGroup
 	hmQueueLen       int
+	maxActive        int
+	taskLimit        int
 	reqWidth         int
+	rateLimit        int64
+	limiter          *internal.Pool
 	refreshRate      time.Duration
 	delayRC          <-chan any
 	manualRC         <-chan any
 	shutdownNotifier chan any
 	depleteHeap      chan<- *Bar
 	queueBars        map[*Bar]*Bar
+	pendingMeta      func(string) string
+	pendingSummary   func(int) string
 	output           io.Writer
 	debugOut         io.Writer
 	cwriter          ConsoleWriter
 	popCompleted     bool
 	autoRefresh      bool
 	forceTTY         bool
+	failFast         bool
 	hasUnrendered    bool
 }
 
@@ -105,6 +122,8 @@ func NewWithContext(ctx context.Context, options ...ContainerOption) *Progress {
 		s.cwriter = cupwriter.New(s.output, s.forceTTY)
 	}
 
+	s.limiter = internal.NewPool(s.rateLimit)
+
 	p := &Progress{
 		ctx:          ctx,
 		cancel:       cancel,
@@ -113,6 +132,8 @@ func NewWithContext(ctx context.Context, options ...ContainerOption) *Progress {
 		operateState: make(chan func(*pState)),
 		interceptIO:  make(chan func(io.Writer)),
 		done:         make(chan struct{}),
+		limiter:      s.limiter,
+		tasks:        newTaskGroup(ctx, s.failFast),
 	}
 
 	var refreshStrategy func(*Progress, *pState)
@@ -166,33 +187,91 @@ func (p *Progress) New(total int64, builder BarFillerBuilder, options ...BarOpti
 // Add creates a bar which renders itself by provided BarFiller.
 // If `total <= 0` triggering complete event by increment methods is disabled.
 // Returns (0, ErrDone[*Progress]) if called after (*Progress).Wait.
+// Returns non nil error if any of BarAfter prerequisites is constructed
+// by another container.
 func (p *Progress) Add(total int64, filler BarFiller, options ...BarOption) (*Bar, error) {
 	if filler == nil {
 		filler = NopStyle().Build()
 	} else if f, ok := filler.(BarFillerFunc); ok && f == nil {
 		filler = NopStyle().Build()
 	}
-	ch := make(chan *Bar, 1)
+	type result struct {
+		bar *Bar
+		err error
+	}
+	ch := make(chan result, 1)
 	select {
 	case p.operateState <- func(s *pState) {
 		bs := s.makeBarState(total, filler, options...)
+		if err := p.checkPrerequisites(bs.after); err != nil {
+			ch <- result{err: err}
+			return
+		}
+		s.idCount++
+		queue := bs.isQueue()
+		taskLimited := bs.task && !queue && s.taskLimit > 0
+		limited := !queue && !taskLimited && s.maxActive > 0
+		dependent := len(bs.after) != 0
+		if dependent {
+			bs.pending = true
+		} else if taskLimited {
+			bs.pending = s.taskCount >= s.taskLimit
+		} else if limited {
+			bs.pending = s.activeCount >= s.maxActive
+		}
 		bar := p.makeBar(bs)
-		if bs.isQueue() {
+		bar.queuedTotal = max(bs.total0, 0)
+		bar.listed = !queue && (dependent || s.pendingMeta != nil)
+		if taskLimited && !bs.pending {
+			bar.task = true
+			s.taskCount++
+		}
+		if limited && !bs.pending {
+			bar.active = true
+			s.activeCount++
+		}
+		switch {
+		case queue:
 			s.queueBars[bs.waitFor] = bar
-		} else if !p.noRenderMode {
+		case bs.pending:
+			switch {
+			case dependent:
+			case taskLimited:
+				s.pendingTasks = append(s.pendingTasks, bar)
+			default:
+				s.pendingBars = append(s.pendingBars, bar)
+			}
+			if bar.listed && !p.noRenderMode {
+				s.hm.push(bar, true, nil)
+			}
+		case !p.noRenderMode:
 			s.hm.push(bar, true, nil)
 		}
+		if dependent {
+			p.bwg.Go(func() {
+				if awaitPrerequisites(bs.after, bs.afterAny) {
+					p.resolveDependent(bar, limited, taskLimited)
+				} else {
+					bar.skip()
+				}
+			})
+		}
 		p.bwg.Go(func() {
 			bar.serve(bs)
+			bar.leaveShare(true)
+			if limited || taskLimited {
+				p.releaseSlot(bar)
+			}
 			for _, group := range bs.decorGroups {
 				p.bwg.Go(func() {
 					decoratorOnShutdown(group)
 				})
 			}
 		})
-		ch <- bar
+		ch <- result{bar: bar}
 	}:
-		return <-ch, nil
+		res := <-ch
+		return res.bar, res.err
 	case <-p.done:
 		return nil, ErrDone[*Progress]{nil}
 	}
@@ -208,6 +287,11 @@ func (p *Progress) makeBar(bs *bState) *Bar {
 		operateState: make(chan func(*bState)),
 		bsOk:         make(chan struct{}),
 		container:    p,
+		limiter:      bs.limiter,
+		scale:        bs.scale,
+	}
+	if bs.pending {
+		bar.activate = make(chan struct{})
 	}
 	if p.noRenderMode {
 		return bar
@@ -223,6 +307,58 @@ func (p *Progress) makeBar(bs *bState) *Bar {
 	return bar
 }
 
+// releaseSlot releases active slot of a bar counted by WithMaxActiveBars
+// and task slot counted by WithTaskLimit.
+func (p *Progress) releaseSlot(b *Bar) {
+	select {
+	case p.operateState <- func(s *pState) {
+		// bar may be done without being activated
+		s.pendingTasks, _ = deleteBar(s.pendingTasks, b)
+		s.pendingBars, _ = deleteBar(s.pendingBars, b)
+		if b.task {
+			b.task = false
+			s.taskCount--
+		}
+		if b.active {
+			b.active = false
+			s.activeCount--
+		}
+		s.activatePending(p.noRenderMode)
+	}:
+	case <-p.done:
+	}
+}
+
+// retirePending takes a bar, which is done while pending, out of the
+// slot queue. Unlisted bar is pushed into the heap, so its final frame
+// is rendered.
+func (p *Progress) retirePending(b *Bar) {
+	select {
+	case p.operateState <- func(s *pState) {
+		b.retired = true
+		var ok1, ok2 bool
+		s.pendingTasks, ok1 = deleteBar(s.pendingTasks, b)
+		s.pendingBars, ok2 = deleteBar(s.pendingBars, b)
+		if !ok1 && !ok2 {
+			return
+		}
+		if !b.listed && !p.noRenderMode {
+			s.hm.push(b, true, nil)
+		}
+	}:
+	case <-p.done:
+	}
+}
+
+// updateQueuedTotal updates total of a pending bar, which is counted by
+// container statistics while bar isn't in the heap.
+func (p *Progress) updateQueuedTotal(b *Bar, total int64) {
+	select {
+	case p.operateState <- func(*pState) { b.queuedTotal = total }:
+	case <-p.done:
+	}
+}
+
 // blocks until iteration is done
 func (p *Progress) iterateBars(yield func(*Bar) bool) error {
 	seqCh := make(chan iter.Seq[*Bar], 1)
@@ -252,6 +388,13 @@ func (p *Progress) UpdateBarPriority(b *Bar, priority int, lazy bool) {
 	}
 }
 
+// SetRateLimit sets throughput limit in bytes per second, which is split
+// evenly among proxies of the container. Zero or negative value
+// removes the limit. See WithRateLimit.
+func (p *Progress) SetRateLimit(bytesPerSec int64) {
+	p.limiter.SetLimit(bytesPerSec)
+}
+
 // Write is implementation of io.Writer. Writing to `*Progress` will print lines
 // above a running bar. Writes aren't flushed immediately, but at next refresh
 // cycle. Returns (0, ErrDone[*Progress]) if called after (*Progress).Wait.
@@ -400,13 +543,15 @@ func (s *pState) render() (err error) {
 	defer close(offload)
 	var total, popCount int
 	var rows [][]io.Reader
+	stats := s.retired
 
-	for b := range s.hm.render(width, offload) {
+	for b := range s.hm.render(width, s.stats, offload) {
 		frame := <-b.frameCh
 		if frame.err != nil {
 			b.cancel(frame.err)
 			return frame.err // b.frameCh is buffered it's ok to return here
 		}
+		aggregate(&stats, frame.stat)
 		var discarded int
 		for _, row := range slices.Backward(frame.rows) {
 			if total < height {
@@ -420,11 +565,12 @@ func (s *pState) render() (err error) {
 
 		switch b.shutdown {
 		case 1:
-			b.cancel(nil)
+			b.cancel(frame.cause)
 			if q, ok := s.queueBars[b]; ok {
 				delete(s.queueBars, b)
 				q.priority = b.priority
 				s.hm.push(q, true, offload)
+				aggregate(&s.retired, frame.stat)
 				continue
 			}
 			if s.popCompleted && !frame.noPop {
@@ -434,11 +580,13 @@ func (s *pState) render() (err error) {
 			}
 			if frame.rmOnComplete {
 				s.hasUnrendered = true
+				aggregate(&s.retired, frame.stat)
 				continue
 			}
 		case 2:
 			if s.popCompleted && !frame.noPop {
 				popCount += len(frame.rows) - discarded
+				aggregate(&s.retired, frame.stat)
 				continue
 			}
 		}
@@ -446,6 +594,24 @@ func (s *pState) render() (err error) {
 		s.hm.push(b, false, offload)
 	}
 
+	for _, b := range slices.Concat(s.pendingTasks, s.pendingBars) {
+		if !b.listed {
+			aggregateQueued(&stats, b)
+		}
+	}
+	for _, b := range s.queueBars {
+		aggregateQueued(&stats, b)
+	}
+	stats.Updated = time.Now()
+	s.stats = &stats
+
+	if n := len(s.pendingTasks) + len(s.pendingBars); s.pendingSummary != nil && n != 0 {
+		// rows are rendered backward, so first one is at the bottom
+		summary := strings.NewReader(s.pendingSummary(n) + "\n")
+		rows = slices.Insert(rows, 0, []io.Reader{summary})
+		total++
+	}
+
 	for _, row := range slices.Backward(rows) {
 		for _, r := range row {
 			n, err := s.cwriter.ReadFrom(r)
@@ -461,13 +627,93 @@ func (s *pState) render() (err error) {
 	return s.cwriter.Flush(total - popCount)
 }
 
+// aggregate adds bar's statistics to container's ones. Values of bars
+// with different scale are converted to the largest one.
+func aggregate(cs *decor.ContainerStatistics, stat decor.Statistics) {
+	scale := max(stat.Scale, 1)
+	cs.Scale = max(cs.Scale, 1)
+	if scale > cs.Scale {
+		cs.Total = rescale(cs.Total, cs.Scale, scale)
+		cs.Current = rescale(cs.Current, cs.Scale, scale)
+		cs.Scale = scale
+	}
+	cs.Total += rescale(stat.Total, scale, cs.Scale)
+	cs.Current += rescale(stat.Current, scale, cs.Scale)
+	switch {
+	case stat.Aborted:
+		cs.Aborted++
+	case stat.Completed:
+		cs.Completed++
+	case stat.Pending:
+		cs.Queued++
+	default:
+		cs.Active++
+	}
+}
+
+// aggregateQueued adds a bar, which is queued and isn't rendered, to
+// container's statistics.
+func aggregateQueued(cs *decor.ContainerStatistics, b *Bar) {
+	aggregate(cs, decor.Statistics{Total: b.queuedTotal, Scale: b.scale, Pending: true})
+}
+
+// rescale converts fixed-point value v from one scale to another.
+func rescale(v, from, to int64) int64 {
+	if from == to {
+		return v
+	}
+	if to%from == 0 {
+		return v * (to / from)
+	}
+	return int64(math.Round(float64(v) / float64(from) * float64(to)))
+}
+
+func (s *pState) activatePending(noRenderMode bool) {
+	for len(s.pendingTasks) != 0 && s.taskCount < s.taskLimit {
+		bar := s.pendingTasks[0]
+		s.pendingTasks = s.pendingTasks[1:]
+		s.taskCount++
+		bar.task = true
+		s.activate(bar, noRenderMode)
+	}
+	for len(s.pendingBars) != 0 && s.activeCount < s.maxActive {
+		bar := s.pendingBars[0]
+		s.pendingBars = s.pendingBars[1:]
+		s.activeCount++
+		bar.active = true
+		s.activate(bar, noRenderMode)
+	}
+}
+
+// deleteBar deletes b from bars, reporting whether it has been there.
+func deleteBar(bars []*Bar, b *Bar) ([]*Bar, bool) {
+	i := slices.Index(bars, b)
+	if i == -1 {
+		return bars, false
+	}
+	return slices.Delete(bars, i, i+1), true
+}
+
+func (s *pState) activate(bar *Bar, noRenderMode bool) {
+	close(bar.activate)
+	// bar is in the heap already if it has been rendered while pending
+	if !bar.listed && !noRenderMode {
+		s.hm.push(bar, true, nil)
+	}
+}
+
 func (s *pState) makeBarState(total int64, filler BarFiller, options ...BarOption) *bState {
 	bs := &bState{
-		id:       s.idCount,
-		priority: s.idCount,
-		reqWidth: s.reqWidth,
-		total0:   cmp.Or(total, -1),
-		filler:   filler,
+		id:          s.idCount,
+		priority:    s.idCount,
+		reqWidth:    s.reqWidth,
+		total0:      cmp.Or(total, -1),
+		scale:       1,
+		created:     time.Now(),
+		filler:      filler,
+		pendingMeta: s.pendingMeta,
+		limiter:     internal.NewLimiter(0),
+		shared:      s.limiter,
 	}
 
 	for _, opt := range options {
@@ -484,6 +730,5 @@ func (s *pState) makeBarState(total int64, filler BarFiller, options ...BarOptio
 	bs.buffers[1] = bytes.NewBuffer(make([]byte, 0, 128)) // prepend
 	bs.buffers[2] = bytes.NewBuffer(make([]byte, 0, 128)) // append
 
-	s.idCount++
 	return bs
 }
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"errors"
+	"fmt"
 	"io"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -371,3 +373,201 @@ func makeUpdateBarPriorityTest(refresh, lazy bool) func(*testing.T) {
 		}
 	}
 }
+
+func TestMaxActiveBars(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithMaxActiveBars(1))
+	a := p.AddBar(1)
+	b := p.AddBar(2)
+
+	done := make(chan struct{})
+	go func() {
+		b.Increment()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatal("Expected increment of pending bar not to block")
+	}
+	if b.Completed() {
+		t.Error("Expected pending bar not to be completed")
+	}
+
+	a.Increment()
+	b.Increment()
+	p.Wait()
+
+	if !b.Completed() {
+		t.Error("Expected bar to be completed")
+	}
+}
+
+func TestMaxActiveBarsAbortPending(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithMaxActiveBars(1))
+	a := p.AddBar(1)
+	b := p.AddBar(1)
+	c := p.AddBar(1)
+
+	done := make(chan struct{})
+	go func() {
+		// slot is held by a
+		b.Abort(false)
+		a.Increment()
+		c.Increment()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+	case <-time.After(timeout):
+		t.Fatal("Expected abort of pending bar not to block")
+	}
+	p.Wait()
+
+	if !b.Aborted() {
+		t.Error("Expected pending bar to be aborted")
+	}
+	if !a.Completed() || !c.Completed() {
+		t.Error("Expected active bars to be completed")
+	}
+}
+
+func TestPendingMetaAndSummary(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(80),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+		mpb.WithMaxActiveBars(1),
+		mpb.WithPendingMeta(func(s string) string { return "<" + s + ">" }),
+		mpb.WithPendingSummary(func(n int) string { return fmt.Sprintf("%d pending", n) }),
+	)
+	pending := func(s decor.Statistics) string {
+		if s.Pending {
+			return "waiting"
+		}
+		return "running"
+	}
+	a := p.New(1, mpb.NopStyle(), mpb.AppendDecorators(decor.Any(pending)))
+	b := p.New(1, mpb.NopStyle(), mpb.AppendDecorators(decor.Any(pending)))
+
+	// third refresh is accepted once the first one is rendered
+	for range 3 {
+		refresh <- time.Now()
+	}
+	a.Increment()
+	b.Increment()
+	p.Wait()
+
+	for _, s := range []string{"running", "<  waiting>", "1 pending"} {
+		if !strings.Contains(buf.String(), s) {
+			t.Errorf("Expected %q in output, got: %q", s, buf.String())
+		}
+	}
+}
+
+func TestContainerStatistics(t *testing.T) {
+	refresh := make(chan any)
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithManualRefresh(refresh))
+
+	var stats atomic.Pointer[decor.ContainerStatistics]
+	a := p.AddBar(10)
+	b := p.AddBar(10)
+	c := p.AddBar(10, mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+		stats.Store(s.Container)
+		return ""
+	})))
+	_ = p.AddBar(10, mpb.BarQueueAfter(c))
+
+	a.SetCurrent(10)
+	b.Abort(false)
+	c.SetCurrent(5)
+	// decorators see statistics aggregated by the previous render
+	for range 4 {
+		refresh <- time.Now()
+	}
+
+	cs := stats.Load()
+	if cs == nil {
+		t.Fatal("Expected container statistics")
+	}
+	// queued bar's total is included
+	expected := decor.ContainerStatistics{
+		Total:     40,
+		Current:   15,
+		Scale:     1,
+		Active:    1,
+		Queued:    1,
+		Completed: 1,
+		Aborted:   1,
+		Updated:   cs.Updated,
+	}
+	if *cs != expected {
+		t.Errorf("Expected: %+v, got: %+v", expected, *cs)
+	}
+	p.Shutdown()
+}
+
+func TestContainerStatisticsMixedScale(t *testing.T) {
+	refresh := make(chan any)
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithManualRefresh(refresh))
+
+	var stats atomic.Pointer[decor.ContainerStatistics]
+	a := p.AddBar(10, mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+		stats.Store(s.Container)
+		return ""
+	})))
+	b := p.AddBar(1000, mpb.BarScale(100))
+	_ = p.AddBar(20, mpb.BarQueueAfter(b))
+
+	a.SetCurrent(5)
+	b.SetCurrentFloat(2.5)
+	for range 4 {
+		refresh <- time.Now()
+	}
+
+	cs := stats.Load()
+	if cs == nil {
+		t.Fatal("Expected container statistics")
+	}
+	// 10 + 10.0 + 20 at scale of 100
+	if cs.Scale != 100 || cs.Total != 4000 || cs.Current != 750 {
+		t.Errorf("Expected total 4000 and current 750 at scale 100, got: %+v", *cs)
+	}
+	p.Shutdown()
+}
+
+func TestContainerStatisticsPendingTotal(t *testing.T) {
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithOutput(io.Discard),
+		mpb.WithManualRefresh(refresh),
+		mpb.WithMaxActiveBars(1),
+	)
+
+	var stats atomic.Pointer[decor.ContainerStatistics]
+	a := p.AddBar(10, mpb.AppendDecorators(decor.Any(func(s decor.Statistics) string {
+		stats.Store(s.Container)
+		return ""
+	})))
+	b := p.AddBar(10)
+	b.SetTotal(30, false)
+
+	// container learns pending bar's total asynchronously
+	var cs *decor.ContainerStatistics
+	for range 100 {
+		refresh <- time.Now()
+		if cs = stats.Load(); cs != nil && cs.Total == 40 {
+			break
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if cs == nil || cs.Total != 40 || cs.Queued != 1 {
+		t.Errorf("Expected total 40 with 1 queued, got: %+v", cs)
+	}
+	a.Abort(false)
+	b.Abort(false)
+	p.Wait()
+}
@@ -0,0 +1,123 @@
+package mpb
+
+import (
+	"bytes"
+	"fmt"
+	"hash"
+	"io"
+)
+
+// ChecksumError is an abort cause of a bar, which reader constructed by
+// (*Bar).ProxyReaderHash has read data with unexpected digest.
+type ChecksumError struct {
+	Expected []byte
+	Actual   []byte
+}
+
+func (e *ChecksumError) Error() string {
+	return fmt.Sprintf("checksum mismatch: expected %x, got %x", e.Expected, e.Actual)
+}
+
+type hashReader struct {
+	readCloser
+	h hash.Hash
+}
+
+func (x hashReader) Read(p []byte) (int, error) {
+	n, err := x.readCloser.Read(p)
+	_, _ = x.h.Write(p[:n]) // hash.Hash never returns an error
+	return n, err
+}
+
+type hashReadWriterTo struct {
+	hashReader
+	src io.WriterTo
+}
+
+func (x hashReadWriterTo) WriteTo(w io.Writer) (int64, error) {
+	return x.src.WriteTo(io.MultiWriter(w, x.h))
+}
+
+type hashVerifier struct {
+	io.ReadCloser
+	bar      *Bar
+	h        hash.Hash
+	expected []byte
+	verified bool
+}
+
+func (x *hashVerifier) Read(p []byte) (int, error) {
+	n, err := x.ReadCloser.Read(p)
+	switch {
+	case err == io.EOF:
+		if e := x.verify(); e != nil {
+			err = e
+		}
+	case err != nil:
+		x.fail(err)
+	}
+	return n, err
+}
+
+// Close verifies digest of data read so far, if it isn't verified yet.
+// Therefore reading exact number of bytes, e.g. by io.CopyN, and closing
+// is as good as reading until EOF.
+func (x *hashVerifier) Close() error {
+	verr := x.verify()
+	if err := x.ReadCloser.Close(); err != nil {
+		return err
+	}
+	return verr
+}
+
+func (x *hashVerifier) verify() error {
+	if x.verified {
+		return nil
+	}
+	var err error
+	if sum := x.h.Sum(nil); !bytes.Equal(sum, x.expected) {
+		err = &ChecksumError{x.expected, sum}
+	}
+	x.verified = true
+	x.bar.releaseComplete(err)
+	return err
+}
+
+// fail aborts the bar with read error, as digest can't be verified.
+func (x *hashVerifier) fail(err error) {
+	if x.verified {
+		return
+	}
+	x.verified = true
+	x.bar.releaseComplete(err)
+}
+
+type hashVerifierWriterTo struct {
+	*hashVerifier
+	src io.WriterTo
+}
+
+func (x hashVerifierWriterTo) WriteTo(w io.Writer) (int64, error) {
+	n, err := x.src.WriteTo(w)
+	if err != nil {
+		x.fail(err)
+		return n, err
+	}
+	return n, x.verify()
+}
+
+func newHashReader(r io.Reader, h hash.Hash) io.Reader {
+	hr := hashReader{readCloser{r}, h}
+	if src, ok := r.(io.WriterTo); ok {
+		return hashReadWriterTo{hr, src}
+	}
+	return hr
+}
+
+func newHashVerifier(b *Bar, rc io.ReadCloser, h hash.Hash, expected []byte) io.ReadCloser {
+	v := &hashVerifier{ReadCloser: rc, bar: b, h: h, expected: expected}
+	if src, ok := rc.(io.WriterTo); ok {
+		return hashVerifierWriterTo{v, src}
+	}
+	return v
+}
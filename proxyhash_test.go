@@ -0,0 +1,147 @@
+package mpb_test
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"errors"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/vbauerster/mpb/v8"
+)
+
+func TestProxyReaderHash(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.New(int64(len(content)), mpb.NopStyle())
+
+	sum := sha256.Sum256([]byte(content))
+	h := sha256.New()
+	tr := &testReaderWriterTo{strings.NewReader(content), false}
+	pr, err := bar.ProxyReaderHash(tr, h, sum[:])
+	if err != nil {
+		t.Fatal(err)
+	}
+	var buf bytes.Buffer
+	_, err = io.Copy(&buf, pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if !tr.called {
+		t.Error("WriteTo not called")
+	}
+	if got := h.Sum(nil); !bytes.Equal(got, sum[:]) {
+		t.Errorf("Expected digest: %x, got: %x", sum, got)
+	}
+	if !bar.Completed() {
+		t.Error("Expected bar to be completed")
+	}
+	p.Wait()
+}
+
+func TestProxyReaderHashMismatch(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.New(int64(len(content)), mpb.NopStyle())
+
+	tr := &testReader{strings.NewReader(content), false}
+	pr, err := bar.ProxyReaderHash(tr, sha256.New(), make([]byte, sha256.Size))
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = io.Copy(io.Discard, pr)
+
+	var checksumErr *mpb.ChecksumError
+	if !errors.As(err, &checksumErr) {
+		t.Fatalf("Expected %T, got: %v", checksumErr, err)
+	}
+	if !bar.Aborted() {
+		t.Error("Expected bar to be aborted")
+	}
+	if bar.Completed() {
+		t.Error("Expected bar not to be completed")
+	}
+	p.Wait()
+}
+
+func TestBarFillerOnAbort(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithWidth(80),
+		mpb.WithOutput(&buf),
+		mpb.WithManualRefresh(refresh),
+	)
+	bar := p.New(int64(len(content)), mpb.BarStyle(),
+		mpb.BarFillerOnAbort("failed"),
+	)
+
+	pr, err := bar.ProxyReaderHash(strings.NewReader(content), sha256.New(), []byte{0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, _ = io.Copy(io.Discard, pr)
+	p.Wait()
+
+	if !strings.Contains(buf.String(), "failed: checksum mismatch: expected ff") {
+		t.Errorf("Expected abort cause in output, got: %q", buf.String())
+	}
+}
+
+func TestProxyReaderHashClose(t *testing.T) {
+	sum := sha256.Sum256([]byte(content))
+	cases := map[string]struct {
+		n         int64
+		completed bool
+	}{
+		"exact":     {int64(len(content)), true},
+		"truncated": {int64(len(content)) / 2, false},
+	}
+	for name, tc := range cases {
+		t.Run(name, func(t *testing.T) {
+			p := mpb.New(mpb.WithOutput(io.Discard))
+			bar := p.New(tc.n, mpb.NopStyle())
+
+			pr, err := bar.ProxyReaderHash(strings.NewReader(content), sha256.New(), sum[:])
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = io.CopyN(io.Discard, pr, tc.n)
+			if err != nil {
+				t.Fatal(err)
+			}
+			err = pr.Close()
+			p.Wait()
+
+			if bar.Completed() != tc.completed {
+				t.Errorf("Expected completed: %t, got: %t", tc.completed, bar.Completed())
+			}
+			var checksumErr *mpb.ChecksumError
+			if !tc.completed && (!errors.As(err, &checksumErr) || !errors.As(bar.Cause(), &checksumErr)) {
+				t.Errorf("Expected %T from Close and as cause, got: %v, %v", checksumErr, err, bar.Cause())
+			}
+		})
+	}
+}
+
+func TestProxyReaderHashReadError(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.New(int64(len(content)), mpb.NopStyle())
+
+	pr, err := bar.ProxyReaderHash(errReader{}, sha256.New(), []byte{0xff})
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, err = io.Copy(io.Discard, pr)
+	if err == nil {
+		t.Fatal("Expected read error")
+	}
+	p.Wait()
+
+	if !bar.Aborted() {
+		t.Error("Expected bar to be aborted")
+	}
+	if cause := bar.Cause(); cause == nil || cause.Error() != "read failure" {
+		t.Errorf("Expected read failure cause, got: %v", cause)
+	}
+}
@@ -0,0 +1,97 @@
+package mpb
+
+import (
+	"io"
+	"time"
+
+	"github.com/vbauerster/mpb/v8/internal"
+)
+
+// limitedRead reads no more than burst size of either bar's or
+// container's limiter and waits until read bytes fit the limit. Without
+// limit it's the same as a plain read.
+func (b *Bar) limitedRead(r io.Reader, p []byte) (int, error) {
+	if burst := b.burst(); burst != 0 && len(p) > burst {
+		p = p[:burst]
+	}
+	n, err := r.Read(p)
+	b.throttle(n)
+	if err == io.EOF {
+		b.leaveShare(false)
+	}
+	return n, err
+}
+
+// limitedWrite writes p in chunks of burst size, waiting before each
+// chunk until it fits the limit. Bar is incremented by each written
+// chunk, with EWMA duration if ewma is set.
+func (b *Bar) limitedWrite(w io.Writer, p []byte, ewma bool) (n int, err error) {
+	burst := b.burst()
+	if burst == 0 {
+		// fast path, there is no limit
+		start := time.Now()
+		n, err = w.Write(p)
+		b.incr(n, start, ewma)
+		return n, err
+	}
+	for len(p) != 0 && err == nil {
+		chunk := p[:min(len(p), burst)]
+		start := time.Now()
+		b.throttle(len(chunk))
+		var nw int
+		nw, err = w.Write(chunk)
+		b.incr(nw, start, ewma)
+		n += nw
+		p = p[nw:]
+	}
+	return n, err
+}
+
+func (b *Bar) incr(n int, start time.Time, ewma bool) {
+	if ewma {
+		b.EwmaIncrBy(n, time.Since(start))
+	} else {
+		b.IncrBy(n)
+	}
+}
+
+// burst returns max chunk size allowed by either bar's limiter or its
+// share of container's limiter, zero means unlimited.
+func (b *Bar) burst() int {
+	x, y := b.limiter.Burst(), b.joinShare().Burst()
+	if x == 0 || y != 0 && y < x {
+		return y
+	}
+	return x
+}
+
+func (b *Bar) throttle(n int) {
+	// bar's ctx is done on abort, no reason to keep waiting then
+	if b.limiter.Wait(b.ctx, n) == nil {
+		_ = b.joinShare().Wait(b.ctx, n)
+	}
+}
+
+// joinShare returns bar's share of container's limiter, joining the pool
+// on first use, so the limit is split evenly among bars which are being
+// proxied at the moment. Nil is returned once the bar is done.
+func (b *Bar) joinShare() *internal.Limiter {
+	b.shareMu.Lock()
+	defer b.shareMu.Unlock()
+	if b.share == nil && !b.unshared {
+		b.share = b.container.limiter.Join()
+	}
+	return b.share
+}
+
+// leaveShare gives bar's share back to the pool, either on EOF or once
+// the bar is done.
+func (b *Bar) leaveShare(done bool) {
+	b.shareMu.Lock()
+	defer b.shareMu.Unlock()
+	if b.share != nil {
+		b.container.limiter.Leave(b.share)
+		b.share = nil
+	}
+	b.unshared = done
+}
@@ -0,0 +1,134 @@
+package mpb_test
+
+import (
+	"bytes"
+	"io"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+)
+
+func TestProxyReaderLimited(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	total := len(content)
+	bar := p.New(int64(total), mpb.NopStyle())
+
+	var buf bytes.Buffer
+	pr, err := bar.ProxyReaderLimited(strings.NewReader(content), int64(total/2))
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = io.Copy(&buf, pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// first half is available right away, second one takes a second
+	if elapsed := time.Since(start); elapsed < 900*time.Millisecond {
+		t.Errorf("Expected throttling about 1s, took: %v", elapsed)
+	}
+
+	if got := buf.String(); got != content {
+		t.Errorf("Expected content: %s, got: %s\n", content, got)
+	}
+	if limit := bar.RateLimit(); limit != int64(total/2) {
+		t.Errorf("Expected rate limit: %d, got: %d", total/2, limit)
+	}
+	p.Wait()
+}
+
+func TestProxyWriterContainerRateLimit(t *testing.T) {
+	total := 3000
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithRateLimit(2000))
+	bar := p.New(int64(total), mpb.NopStyle())
+
+	var buf bytes.Buffer
+	pw, err := bar.ProxyWriter(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	start := time.Now()
+	_, err = io.Copy(pw, strings.NewReader(strings.Repeat("x", total)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2000 bytes are available right away, remaining 1000 take 500ms
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected throttling about 500ms, took: %v", elapsed)
+	}
+	if buf.Len() != total {
+		t.Errorf("Expected written: %d, got: %d", total, buf.Len())
+	}
+	p.Wait()
+}
+
+func TestProxyReaderContainerRateLimitShared(t *testing.T) {
+	total := 3000
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithRateLimit(2000))
+
+	var wg sync.WaitGroup
+	var bars [2]*mpb.Bar
+	for i := range bars {
+		bars[i] = p.New(int64(total), mpb.NopStyle())
+		pr, err := bars[i].ProxyReader(strings.NewReader(strings.Repeat("x", total)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		wg.Go(func() {
+			_, _ = io.Copy(io.Discard, pr)
+		})
+	}
+
+	// with a single budget one proxy would wait for the other one
+	deadline := time.Now().Add(700 * time.Millisecond)
+	for time.Now().Before(deadline) && (bars[0].Current() == 0 || bars[1].Current() == 0) {
+		time.Sleep(10 * time.Millisecond)
+	}
+	for i, b := range bars {
+		if b.Current() == 0 {
+			t.Errorf("Expected bar %d to make progress", i)
+		}
+	}
+	wg.Wait()
+	p.Wait()
+}
+
+func TestProxyReaderSetRateLimitLater(t *testing.T) {
+	total := 3000
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.New(int64(total), mpb.NopStyle())
+
+	// proxy is constructed while there is no limit at all
+	pr, err := bar.ProxyReader(strings.NewReader(strings.Repeat("x", total)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	bar.SetRateLimit(2000)
+	start := time.Now()
+	n, err := io.Copy(io.Discard, pr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2000 bytes are available right away, remaining 1000 take 500ms
+	if elapsed := time.Since(start); elapsed < 400*time.Millisecond {
+		t.Errorf("Expected throttling about 500ms, took: %v", elapsed)
+	}
+	if n != int64(total) {
+		t.Errorf("Expected read: %d, got: %d", total, n)
+	}
+	p.Wait()
+}
+
+func TestRateLimitDecorator(t *testing.T) {
+	d := decor.RateLimit(decor.SizeB1024(0), "% .1f")
+	if str, _ := d.Decor(decor.Statistics{}); str != "" {
+		t.Errorf("Expected empty string, got: %q", str)
+	}
+	if str, _ := d.Decor(decor.Statistics{RateLimit: 1024 * 1024}); str != "1.0 MiB/s" {
+		t.Errorf("Expected %q, got: %q", "1.0 MiB/s", str)
+	}
+}
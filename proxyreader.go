@@ -22,7 +22,7 @@ type proxyReader struct {
 }
 
 func (x proxyReader) Read(p []byte) (int, error) {
-	n, err := x.readCloser.Read(p)
+	n, err := x.bar.limitedRead(x.readCloser, p)
 	x.bar.IncrBy(n)
 	return n, err
 }
@@ -48,7 +48,7 @@ type ewmaProxyReadWriterTo struct {
 // not be used at all. Just keeping it for manual Read cases.
 func (x ewmaProxyReadWriterTo) Read(p []byte) (int, error) {
 	start := time.Now()
-	n, err := x.readCloser.Read(p)
+	n, err := x.bar.limitedRead(x.readCloser, p)
 	x.bar.EwmaIncrBy(n, time.Since(start))
 	return n, err
 }
@@ -11,7 +11,7 @@ type proxyReadSeeker struct {
 }
 
 func (x proxyReadSeeker) Read(p []byte) (int, error) {
-	n, err := x.rs.Read(p)
+	n, err := x.bar.limitedRead(x.rs, p)
 	x.bar.IncrBy(n)
 	return n, err
 }
@@ -38,7 +38,7 @@ type ewmaProxyReadSeeker struct {
 
 func (x ewmaProxyReadSeeker) Read(p []byte) (int, error) {
 	start := time.Now()
-	n, err := x.rs.Read(p)
+	n, err := x.bar.limitedRead(x.rs, p)
 	x.bar.EwmaIncrBy(n, time.Since(start))
 	return n, err
 }
@@ -2,7 +2,6 @@ package mpb
 
 import (
 	"io"
-	"time"
 )
 
 type writeCloser struct {
@@ -22,9 +21,7 @@ type proxyWriter struct {
 }
 
 func (x proxyWriter) Write(p []byte) (int, error) {
-	n, err := x.writeCloser.Write(p)
-	x.bar.IncrBy(n)
-	return n, err
+	return x.bar.limitedWrite(x.writeCloser, p, false)
 }
 
 type proxyWriteReaderFrom struct {
@@ -47,10 +44,7 @@ type ewmaProxyWriteReaderFrom struct {
 // If io.Copy(ewmaProxyWriteReaderFrom, src) is used then this Write method will
 // not be used at all. Just keeping it for manual Write cases.
 func (x ewmaProxyWriteReaderFrom) Write(p []byte) (int, error) {
-	start := time.Now()
-	n, err := x.writeCloser.Write(p)
-	x.bar.EwmaIncrBy(n, time.Since(start))
-	return n, err
+	return x.bar.limitedWrite(x.writeCloser, p, true)
 }
 
 func (x ewmaProxyWriteReaderFrom) ReadFrom(r io.Reader) (int64, error) {
@@ -0,0 +1,61 @@
+package mpb
+
+import (
+	"iter"
+	"time"
+)
+
+// Seq wraps seq so the bar is incremented by one for each yielded element.
+// Duration between consecutive elements is passed to (*Bar).EwmaIncrement,
+// so EWMA based decorators work with no extra code. Once seq is exhausted
+// the bar is completed, even if its total doesn't match number of elements.
+// If consumer breaks early the bar is aborted.
+func Seq[T any](bar *Bar, seq iter.Seq[T]) iter.Seq[T] {
+	return func(yield func(T) bool) {
+		start := time.Now()
+		for v := range seq {
+			if !yield(v) {
+				bar.Abort(false)
+				return
+			}
+			bar.EwmaIncrement(time.Since(start))
+			start = time.Now()
+		}
+		bar.SetTotal(-1, true)
+	}
+}
+
+// Seq2 is the iter.Seq2 counterpart of Seq.
+func Seq2[K, V any](bar *Bar, seq iter.Seq2[K, V]) iter.Seq2[K, V] {
+	return func(yield func(K, V) bool) {
+		start := time.Now()
+		for k, v := range seq {
+			if !yield(k, v) {
+				bar.Abort(false)
+				return
+			}
+			bar.EwmaIncrement(time.Since(start))
+			start = time.Now()
+		}
+		bar.SetTotal(-1, true)
+	}
+}
+
+// Range returns an iterator over integers [0, n), driving a bar of total
+// n with default bar filler. See Seq for details. The bar is constructed
+// on iteration start, therefore iterating more than once constructs more
+// than one bar with the same options, which isn't safe if options contain
+// decorators. Panics if iterated after (*Progress).Wait.
+func (p *Progress) Range(n int, options ...BarOption) iter.Seq[int] {
+	return func(yield func(int) bool) {
+		bar := p.AddBar(int64(n), options...)
+		seq := func(yield func(int) bool) {
+			for i := range n {
+				if !yield(i) {
+					return
+				}
+			}
+		}
+		Seq(bar, seq)(yield)
+	}
+}
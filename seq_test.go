@@ -0,0 +1,80 @@
+package mpb_test
+
+import (
+	"io"
+	"maps"
+	"slices"
+	"testing"
+
+	"github.com/vbauerster/mpb/v8"
+	"github.com/vbauerster/mpb/v8/decor"
+)
+
+func TestSeq(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	values := []int{1, 2, 3, 4, 5}
+	bar := p.AddBar(int64(len(values)),
+		mpb.AppendDecorators(decor.EwmaETA(decor.ET_STYLE_GO, 30)),
+	)
+
+	var sum int
+	for v := range mpb.Seq(bar, slices.Values(values)) {
+		sum += v
+	}
+
+	if sum != 15 {
+		t.Errorf("Expected sum: 15, got: %d", sum)
+	}
+	if !bar.Completed() {
+		t.Error("Expected bar to be completed")
+	}
+	p.Wait()
+}
+
+func TestSeq2UnknownTotal(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.AddBar(0)
+
+	var count int
+	for range mpb.Seq2(bar, maps.All(map[string]int{"a": 1, "b": 2})) {
+		count++
+	}
+
+	if !bar.Completed() {
+		t.Error("Expected bar to be completed")
+	}
+	if current := bar.Current(); current != int64(count) {
+		t.Errorf("Expected current: %d, got: %d", count, current)
+	}
+	p.Wait()
+}
+
+func TestSeqBreak(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	bar := p.AddBar(10)
+
+	for i := range mpb.Seq(bar, slices.Values(make([]int, 10))) {
+		if i == 0 {
+			break
+		}
+	}
+
+	if !bar.Aborted() {
+		t.Error("Expected bar to be aborted")
+	}
+	p.Wait()
+}
+
+func TestProgressRange(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+
+	var last int
+	for i := range p.Range(10) {
+		last = i
+	}
+
+	if last != 9 {
+		t.Errorf("Expected last: 9, got: %d", last)
+	}
+	p.Wait()
+}
@@ -0,0 +1,114 @@
+package mpb
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/vbauerster/mpb/v8/decor"
+)
+
+type taskGroup struct {
+	ctx      context.Context
+	cancel   context.CancelCauseFunc
+	wg       sync.WaitGroup
+	mu       sync.Mutex
+	errs     []error
+	failFast bool
+}
+
+func newTaskGroup(ctx context.Context, failFast bool) *taskGroup {
+	ctx, cancel := context.WithCancelCause(ctx)
+	g := &taskGroup{
+		ctx:      ctx,
+		cancel:   cancel,
+		failFast: failFast,
+	}
+	return g
+}
+
+// acquire blocks until task's bar is active, see WithTaskLimit and
+// WithMaxActiveBars.
+func (g *taskGroup) acquire(b *Bar) error {
+	if b.activate != nil {
+		select {
+		case <-b.activate:
+		case <-b.ctx.Done():
+			return context.Cause(b.ctx)
+		case <-g.ctx.Done():
+			return context.Cause(g.ctx)
+		}
+	}
+	// slot could be freed by the task which has just failed
+	if g.ctx.Err() != nil {
+		return context.Cause(g.ctx)
+	}
+	return nil
+}
+
+func (g *taskGroup) fail(err error) {
+	g.mu.Lock()
+	g.errs = append(g.errs, err)
+	g.mu.Unlock()
+	if g.failFast {
+		g.cancel(err)
+	}
+}
+
+func (g *taskGroup) err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return errors.Join(g.errs...)
+}
+
+// Go runs fn in a new goroutine, tracking it with a new bar of provided
+// total, constructed with default bar filler and prepended `name`
+// decorator. If fn returns nil the bar is completed, otherwise it's
+// aborted with returned error as its cause and the error is collected to
+// be returned by (*Progress).WaitErr. Number of concurrently running tasks
+// can be limited with WithTaskLimit, tasks which are waiting for a slot are
+// pending bars, which are rendered according to WithPendingMeta and
+// WithPendingSummary. Context passed to fn is canceled once the bar is
+// aborted, e.g. by BarTimeout or BarStallAbort, on container shutdown or
+// on first failed task if WithFailFast is set, in the latter case waiting
+// tasks are never run and their bars are aborted.
+func (p *Progress) Go(name string, total int64, fn func(ctx context.Context, b *Bar) error, options ...BarOption) {
+	options = append([]BarOption{
+		PrependDecorators(decor.Name(name, decor.WC{C: decor.DindentRight | decor.DextraSpace})),
+	}, options...)
+	options = append(options, func(s *bState) { s.task = true })
+	bar, err := p.Add(total, barStyleComposer.Build(), options...)
+	if err != nil {
+		p.tasks.fail(fmt.Errorf("%s: %w", name, err))
+		return
+	}
+	p.tasks.wg.Go(func() {
+		if err := p.tasks.acquire(bar); err != nil {
+			bar.abort(false, err)
+			return
+		}
+		ctx, cancel := context.WithCancelCause(bar.Context())
+		defer cancel(nil)
+		stop := context.AfterFunc(p.tasks.ctx, func() {
+			cancel(context.Cause(p.tasks.ctx))
+		})
+		defer stop()
+		if err := fn(ctx, bar); err != nil {
+			p.tasks.fail(fmt.Errorf("%s: %w", name, err))
+			bar.abort(false, err)
+			return
+		}
+		// total might have been inaccurate or unknown at all
+		bar.SetTotal(-1, true)
+	})
+}
+
+// WaitErr is same as Wait, but it also waits for all tasks started by
+// (*Progress).Go and returns their errors joined by errors.Join. Each
+// error is prefixed with name of the task.
+func (p *Progress) WaitErr() error {
+	p.tasks.wg.Wait()
+	p.Wait()
+	return p.tasks.err()
+}
@@ -0,0 +1,229 @@
+package mpb_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/vbauerster/mpb/v8"
+)
+
+func TestProgressGo(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard))
+	errFail := errors.New("fail")
+
+	var bars [3]*mpb.Bar
+	for i := range bars {
+		p.Go("task", 10, func(_ context.Context, b *mpb.Bar) error {
+			bars[i] = b
+			if i == 1 {
+				b.IncrBy(5)
+				return errFail
+			}
+			b.IncrBy(5) // completed regardless of total
+			return nil
+		})
+	}
+
+	err := p.WaitErr()
+	if !errors.Is(err, errFail) {
+		t.Fatalf("Expected %v, got: %v", errFail, err)
+	}
+	if err.Error() != "task: fail" {
+		t.Errorf("Expected error: %q, got: %q", "task: fail", err.Error())
+	}
+	for i, b := range bars {
+		if i == 1 {
+			if !b.Aborted() {
+				t.Errorf("Expected bar %d to be aborted", i)
+			}
+		} else if !b.Completed() {
+			t.Errorf("Expected bar %d to be completed", i)
+		}
+	}
+}
+
+func TestProgressGoTaskLimit(t *testing.T) {
+	limit := 2
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithTaskLimit(limit))
+
+	var running, maxRunning atomic.Int32
+	for range 6 {
+		p.Go("task", 1, func(_ context.Context, b *mpb.Bar) error {
+			n := running.Add(1)
+			defer running.Add(-1)
+			for {
+				m := maxRunning.Load()
+				if n <= m || maxRunning.CompareAndSwap(m, n) {
+					break
+				}
+			}
+			time.Sleep(10 * time.Millisecond)
+			b.Increment()
+			return nil
+		})
+	}
+
+	if err := p.WaitErr(); err != nil {
+		t.Fatal(err)
+	}
+	if n := maxRunning.Load(); n > int32(limit) {
+		t.Errorf("Expected no more than %d running tasks, got: %d", limit, n)
+	}
+}
+
+func TestProgressGoFailFast(t *testing.T) {
+	p := mpb.New(
+		mpb.WithOutput(io.Discard),
+		mpb.WithTaskLimit(1),
+		mpb.WithFailFast(),
+	)
+	errFail := errors.New("fail")
+
+	started, fail := make(chan struct{}), make(chan struct{})
+	p.Go("first", 1, func(context.Context, *mpb.Bar) error {
+		close(started)
+		<-fail
+		return errFail
+	})
+	<-started
+
+	var queuedRun atomic.Bool
+	for range 2 {
+		p.Go("queued", 1, func(context.Context, *mpb.Bar) error {
+			queuedRun.Store(true)
+			return nil
+		})
+	}
+	close(fail)
+
+	err := p.WaitErr()
+	if !errors.Is(err, errFail) {
+		t.Fatalf("Expected %v, got: %v", errFail, err)
+	}
+	if queuedRun.Load() {
+		t.Error("Expected queued tasks not to run")
+	}
+}
+
+func TestProgressGoPendingSummary(t *testing.T) {
+	var buf bytes.Buffer
+	refresh := make(chan any)
+	p := mpb.New(
+		mpb.WithOutput(&buf),
+		mpb.WithWidth(80),
+		mpb.WithManualRefresh(refresh),
+		mpb.WithTaskLimit(1),
+		mpb.WithPendingSummary(func(n int) string {
+			return fmt.Sprintf("%d waiting", n)
+		}),
+	)
+
+	started, done := make(chan struct{}), make(chan struct{})
+	p.Go("first", 1, func(context.Context, *mpb.Bar) error {
+		close(started)
+		<-done
+		return nil
+	})
+	<-started
+	p.Go("second", 1, func(context.Context, *mpb.Bar) error {
+		return nil
+	})
+	for range 3 {
+		refresh <- time.Now()
+	}
+	close(done)
+
+	if err := p.WaitErr(); err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(buf.String(), "1 waiting") {
+		t.Errorf("Expected queued task in pending summary, got: %q", buf.String())
+	}
+}
+
+func TestProgressGoTaskLimitSeparate(t *testing.T) {
+	p := mpb.New(
+		mpb.WithOutput(io.Discard),
+		mpb.WithMaxActiveBars(1),
+		mpb.WithTaskLimit(2),
+	)
+
+	started, done := make(chan struct{}, 2), make(chan struct{})
+	for range 2 {
+		p.Go("task", 1, func(context.Context, *mpb.Bar) error {
+			started <- struct{}{}
+			<-done
+			return nil
+		})
+	}
+	// tasks aren't limited by WithMaxActiveBars
+	for range 2 {
+		select {
+		case <-started:
+		case <-time.After(2 * time.Second):
+			t.Fatal("Expected both tasks to run")
+		}
+	}
+	bar := p.AddBar(1)
+	bar.Increment()
+	close(done)
+
+	if err := p.WaitErr(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProgressGoTaskLimitIgnoresBars(t *testing.T) {
+	p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithTaskLimit(1))
+
+	bar := p.AddBar(1)
+	ran := make(chan struct{})
+	p.Go("task", 1, func(context.Context, *mpb.Bar) error {
+		close(ran)
+		return nil
+	})
+	select {
+	case <-ran:
+	case <-time.After(2 * time.Second):
+		t.Fatal("Expected task not to wait for a non-task bar")
+	}
+	bar.Increment()
+
+	if err := p.WaitErr(); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestProgressGoContextBarAborted(t *testing.T) {
+	tests := map[string]struct {
+		option mpb.BarOption
+		cause  error
+	}{
+		"timeout": {mpb.BarTimeout(20 * time.Millisecond), context.DeadlineExceeded},
+		"stall":   {mpb.BarStallAbort(20 * time.Millisecond), mpb.ErrStalled},
+	}
+	for name, tc := range tests {
+		t.Run(name, func(t *testing.T) {
+			p := mpb.New(mpb.WithOutput(io.Discard), mpb.WithRefreshRate(10*time.Millisecond))
+			p.Go("task", 1, func(ctx context.Context, _ *mpb.Bar) error {
+				select {
+				case <-ctx.Done():
+					return context.Cause(ctx)
+				case <-time.After(2 * time.Second):
+					return errors.New("context isn't canceled")
+				}
+			}, tc.option)
+
+			if err := p.WaitErr(); !errors.Is(err, tc.cause) {
+				t.Errorf("Expected %v, got: %v", tc.cause, err)
+			}
+		})
+	}
+}